@@ -0,0 +1,145 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// BACKUP & ARTIFACT PERMUTATIONS
+// ===========================================================================
+
+const SourceBackup = "backup"
+
+var (
+	// Appended to the full name: index.php -> index.php.bak
+	backupSuffixes = []string{".bak", ".old", ".orig", ".save", ".tmp", ".copy", ".1", "~", "_bak", "_old"}
+	// Replace the extension: index.php -> index.bak
+	backupExtSwaps = []string{".bak", ".old", ".txt"}
+	// Tried for extensionless hits (usually directories): config -> config.zip
+	archiveSuffixes = []string{".zip", ".tar.gz", ".tgz", ".tar", ".rar", ".7z", ".gz"}
+)
+
+// BackupVariants returns the backup, editor and archive variants worth
+// probing for a path: copies left behind by admins (.bak, .old, .orig),
+// editor leftovers (~, vim swap files, emacs #autosave#) and archives of
+// extensionless paths.
+func BackupVariants(p string) []string {
+	p = strings.Trim(strings.SplitN(p, "?", 2)[0], "/")
+	if p == "" {
+		return nil
+	}
+	dir, name := path.Split(p)
+	if name == "" {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var variants []string
+	add := func(variantName string) {
+		variant := dir + variantName
+		if variant != p && !seen[variant] {
+			seen[variant] = true
+			variants = append(variants, variant)
+		}
+	}
+
+	for _, suffix := range backupSuffixes {
+		add(name + suffix)
+	}
+	// vim hides a swap file behind a dot, unless the name already has one
+	swap := name
+	if !strings.HasPrefix(swap, ".") {
+		swap = "." + swap
+	}
+	add(swap + ".swp")
+	add(swap + ".swo")
+	add("%23" + name + "%23")
+
+	ext := path.Ext(name)
+	if ext != "" && ext != name {
+		base := strings.TrimSuffix(name, ext)
+		for _, swap := range backupExtSwaps {
+			add(base + swap)
+		}
+		add(base + "_old" + ext)
+		add(base + ".old" + ext)
+	} else {
+		for _, suffix := range archiveSuffixes {
+			add(name + suffix)
+		}
+	}
+	return variants
+}
+
+// isBackupCandidate reports whether a result is a confirmed hit worth
+// permuting - found content, or something protected that might have an
+// unprotected copy lying next to it.
+func isBackupCandidate(result *ScanResult) bool {
+	switch result.FinalStatus {
+	case 200, 401, 403:
+		return true
+	}
+	return false
+}
+
+// backupResult either records a variant that turned up, or queues the
+// variants of a fresh hit. Variants are never permuted again.
+func (s *Scanner) backupResult(result *ScanResult) {
+	if result.VariantOf != "" {
+		if result.FinalStatus == 200 {
+			s.Stats.mu.Lock()
+			s.Stats.Backups = append(s.Stats.Backups, result)
+			s.Stats.mu.Unlock()
+		}
+		return
+	}
+	if !isBackupCandidate(result) {
+		return
+	}
+
+	// The link is recorded before queueing so a worker can't scan the
+	// variant before it knows where it came from
+	for _, variant := range BackupVariants(result.OriginalPath) {
+		key := visitKey(variant)
+		s.pathMutex.Lock()
+		_, linked := s.variantOf[key]
+		if !linked {
+			s.variantOf[key] = result
+		}
+		s.pathMutex.Unlock()
+		if linked {
+			continue
+		}
+
+		if !s.EnqueuePath(variant, SourceBackup) {
+			s.pathMutex.Lock()
+			delete(s.variantOf, key)
+			s.pathMutex.Unlock()
+		}
+	}
+}
+
+// variantParent returns the hit a queued variant was derived from, if any
+func (s *Scanner) variantParent(p string) *ScanResult {
+	s.pathMutex.Lock()
+	defer s.pathMutex.Unlock()
+	return s.variantOf[visitKey(p)]
+}
+
+// SortedBackups returns the variants that were found, grouped by original
+func (s *Scanner) SortedBackups() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.Backups))
+	copy(sorted, s.Stats.Backups)
+	s.Stats.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].VariantOf != sorted[j].VariantOf {
+			return sorted[i].VariantOf < sorted[j].VariantOf
+		}
+		return sorted[i].OriginalPath < sorted[j].OriginalPath
+	})
+	return sorted
+}
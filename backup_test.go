@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBackupVariants(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"index.php", []string{
+			"index.php.bak", "index.php.old", "index.php.orig", "index.php.save", "index.php.tmp",
+			"index.php.copy", "index.php.1", "index.php~", "index.php_bak", "index.php_old",
+			".index.php.swp", ".index.php.swo", "%23index.php%23",
+			"index.bak", "index.old", "index.txt", "index_old.php", "index.old.php",
+		}},
+		{"/admin/config/", []string{
+			"admin/config.bak", "admin/config.old", "admin/config.orig", "admin/config.save", "admin/config.tmp",
+			"admin/config.copy", "admin/config.1", "admin/config~", "admin/config_bak", "admin/config_old",
+			"admin/.config.swp", "admin/.config.swo", "admin/%23config%23",
+			"admin/config.zip", "admin/config.tar.gz", "admin/config.tgz", "admin/config.tar",
+			"admin/config.rar", "admin/config.7z", "admin/config.gz",
+		}},
+		// A dotfile's "extension" is its whole name, so it gets archives
+		// rather than extension swaps
+		{".env?x=1", []string{
+			".env.bak", ".env.old", ".env.orig", ".env.save", ".env.tmp",
+			".env.copy", ".env.1", ".env~", ".env_bak", ".env_old",
+			".env.swp", ".env.swo", "%23.env%23",
+			".env.zip", ".env.tar.gz", ".env.tgz", ".env.tar", ".env.rar", ".env.7z", ".env.gz",
+		}},
+		{"", nil},
+		{"/", nil},
+	}
+	for _, tt := range tests {
+		if got := BackupVariants(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BackupVariants(%q) =\n%q\nwant\n%q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestBackupVariantsExcludeOriginal(t *testing.T) {
+	// "config.old" swapped to .old is itself, and must not be probed twice
+	for _, variant := range BackupVariants("config.old") {
+		if variant == "config.old" {
+			t.Errorf("BackupVariants(%q) includes the original path", "config.old")
+		}
+	}
+}
@@ -11,7 +11,7 @@ if exist pathfinder-linux del pathfinder-linux
 if exist pathfinder-mac del pathfinder-mac
 
 echo [2/3] Building for Windows...
-go build -ldflags="-s -w" -o pathfinder.exe main.go
+go build -ldflags="-s -w" -o pathfinder.exe .
 if %ERRORLEVEL% NEQ 0 (
     echo ERROR: Build failed!
     exit /b 1
@@ -21,10 +21,10 @@ echo SUCCESS: pathfinder.exe created
 echo [3/3] Building for Linux and Mac...
 set GOOS=linux
 set GOARCH=amd64
-go build -ldflags="-s -w" -o pathfinder-linux main.go
+go build -ldflags="-s -w" -o pathfinder-linux .
 set GOOS=darwin
 set GOARCH=amd64
-go build -ldflags="-s -w" -o pathfinder-mac main.go
+go build -ldflags="-s -w" -o pathfinder-mac .
 set GOOS=windows
 set GOARCH=amd64
 
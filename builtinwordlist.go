@@ -0,0 +1,180 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// BUILT-IN WORDLIST (-wordlist, -category)
+// ===========================================================================
+
+// The curated wordlist is compiled in, so the binary scans from any working
+// directory. -wordlist replaces it with a file.
+//
+//go:embed wordlist.txt
+var embeddedWordlist string
+
+// Named slices of a wordlist. The curated list groups its entries under
+// banner headings:
+//
+//	# ====================
+//	# CMS & PLATFORMS
+//	# ====================
+//
+// and a category takes every entry under a heading matching its pattern.
+// A one-line heading in capitals ("# CHINESE GOVERNMENT") starts a
+// subsection, which belongs to a category when its section does or when it
+// matches itself. Other comments ("# WordPress") change nothing.
+var wordlistCategories = map[string]*regexp.Regexp{
+	"government": regexp.MustCompile(`(?i)\b(government|ministries|immigration|inm|transparency|legislative|judicial|electoral|oversight|administrative divisions)\b`),
+	"military":   regexp.MustCompile(`(?i)\b(military|armed forces|army|navy|air force|defense|peacekeeping)\b`),
+	"api":        regexp.MustCompile(`(?i)\b(api|webhooks)\b`),
+	"cms":        regexp.MustCompile(`(?i)\bcms\b`),
+}
+
+// WordlistCategoryNames returns the -category names, sorted
+func WordlistCategoryNames() []string {
+	names := make([]string, 0, len(wordlistCategories))
+	for name := range wordlistCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateWordlistCategories checks -category names before a scan starts
+func ValidateWordlistCategories(names []string) error {
+	for _, name := range names {
+		if _, ok := wordlistCategories[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("unknown wordlist category %q (have: %s)", name, strings.Join(WordlistCategoryNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// parseWordlist returns a wordlist's entries, skipping blank lines and
+// comments
+func parseWordlist(data string) []string {
+	var paths []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			paths = append(paths, line)
+		}
+	}
+	return paths
+}
+
+// isBannerRule reports whether a comment is a "# =====" or "# -----" line
+func isBannerRule(comment string) bool {
+	return len(comment) >= 10 && (strings.Trim(comment, "=") == "" || strings.Trim(comment, "-") == "")
+}
+
+// isSubheading reports whether a plain comment is a one-line heading: all
+// capitals, like "# RUSSIAN GOVERNMENT" or "# ARGENTINA"
+func isSubheading(comment string) bool {
+	return strings.ToUpper(comment) == comment && strings.ToLower(comment) != comment
+}
+
+// categoryWords returns the entries of every section whose heading matches
+// one of the categories, in file order and without duplicates
+func categoryWords(data string, categories []string) []string {
+	var patterns []*regexp.Regexp
+	for _, name := range categories {
+		if re, ok := wordlistCategories[strings.ToLower(name)]; ok {
+			patterns = append(patterns, re)
+		}
+	}
+
+	matches := func(heading string) bool {
+		for _, re := range patterns {
+			if re.MatchString(heading) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var words []string
+	afterRule, afterHeading := false, false
+	inSection, inCategory := false, false
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			comment := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			if isBannerRule(comment) {
+				// The rule under a heading closes the banner
+				afterRule = !afterHeading
+				afterHeading = false
+				continue
+			}
+			afterHeading = afterRule
+			if afterRule {
+				// A heading: the comment right under an opening rule
+				inSection = matches(comment)
+				inCategory = inSection
+			} else if isSubheading(comment) {
+				inCategory = inSection || matches(comment)
+			}
+			afterRule = false
+			continue
+		}
+		afterRule, afterHeading = false, false
+		if line != "" && inCategory {
+			words = append(words, line)
+		}
+	}
+	return dedupeWords(words)
+}
+
+// LoadScanWordlist loads the paths to scan: the -wordlist file if one was
+// given, the built-in list otherwise, narrowed to -category. A generator
+// loads nothing; ScanAll streams it.
+func LoadScanWordlist(config *Config) ([]string, error) {
+	if config.Generator != nil {
+		if len(config.Categories) > 0 {
+			return nil, fmt.Errorf("-category needs a wordlist, not a generator")
+		}
+		return nil, nil
+	}
+
+	data := embeddedWordlist
+	if config.Wordlist != "" {
+		content, err := os.ReadFile(config.Wordlist)
+		if err != nil {
+			return nil, err
+		}
+		data = string(content)
+	}
+
+	if len(config.Categories) == 0 {
+		return parseWordlist(data), nil
+	}
+	words := categoryWords(data, config.Categories)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no entries in categories %s", strings.Join(config.Categories, ", "))
+	}
+	return words, nil
+}
+
+// wordlistName names the wordlist in use for the dashboard:
+// "built-in", "list.txt", "built-in [api,cms]", or the generator spec
+func wordlistName(config *Config) string {
+	if config.Generator != nil {
+		return config.Generator.Spec
+	}
+	name := "built-in"
+	if config.Wordlist != "" {
+		name = filepath.Base(config.Wordlist)
+	}
+	if len(config.Categories) > 0 {
+		name += " [" + strings.Join(config.Categories, ",") + "]"
+	}
+	return name
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategoryWords(t *testing.T) {
+	const data = `# =====================
+# ADMIN PANELS
+# =====================
+admin
+
+# =====================
+# CMS & PLATFORMS
+# =====================
+# WordPress
+wp-admin
+# Joomla
+administrator
+
+# =====================
+# RUSSIAN (Russia)
+# =====================
+glavnaya
+
+# RUSSIAN GOVERNMENT
+pravitelstvo
+gosuslugi
+
+# =====================
+# COUNTRY-SPECIFIC GOVERNMENT STRUCTURES
+# =====================
+
+# ARGENTINA
+casarosada
+# These work on many portals
+datos
+`
+	tests := []struct {
+		categories []string
+		want       []string
+	}{
+		{[]string{"cms"}, []string{"wp-admin", "administrator"}},
+		{[]string{"government"}, []string{"pravitelstvo", "gosuslugi", "casarosada", "datos"}},
+		{[]string{"CMS", "api"}, []string{"wp-admin", "administrator"}},
+		{[]string{"military"}, nil},
+	}
+	for _, tt := range tests {
+		got := categoryWords(data, tt.categories)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("categoryWords(%v) = %q, want %q", tt.categories, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryWordsBuiltIn(t *testing.T) {
+	tests := map[string][]string{
+		"government": {"gobierno", "zhengfu", "guowuyuan", "pravitelstvo", "ministerio"},
+		"military":   {"fuerzas-armadas", "ejercito", "armada"},
+		"api":        {"api", "api/v1", "webhook"},
+		"cms":        {"wp-content", "wp-includes"},
+	}
+	for category, expected := range tests {
+		words := make(map[string]bool)
+		for _, w := range categoryWords(embeddedWordlist, []string{category}) {
+			words[w] = true
+		}
+		for _, w := range expected {
+			if !words[w] {
+				t.Errorf("-category %s is missing %q", category, w)
+			}
+		}
+	}
+}
+
+func TestValidateWordlistCategories(t *testing.T) {
+	if err := ValidateWordlistCategories([]string{"api", "CMS"}); err != nil {
+		t.Errorf("valid categories rejected: %v", err)
+	}
+	if err := ValidateWordlistCategories([]string{"api", "nope"}); err == nil {
+		t.Error("unknown category accepted")
+	}
+}
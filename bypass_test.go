@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestScanner returns a scanner for a test server, without any of the
+// optional stages turned on
+func newTestScanner(t *testing.T, baseURL string, config *Config) *Scanner {
+	t.Helper()
+	if config.Method == "" {
+		config.Method = "GET"
+	}
+	return NewScanner(baseURL, 1, 5, false, config)
+}
+
+func TestCheckBypassRewriteHomePage(t *testing.T) {
+	// Ignores X-Original-URL and X-Rewrite-URL: / is the host's home page,
+	// which differs from the /app base path's
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte("host home page"))
+		case "/app/":
+			w.Write([]byte("app home page"))
+		case "/app/secret":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("forbidden"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	s := newTestScanner(t, server.URL+"/app", &Config{Bypass: true})
+	result, err := s.fetchWithOptions(server.URL+"/app/secret", requestOptions{NoFollow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.checkBypass(result)
+	for _, b := range result.Bypasses {
+		if b.Technique == "X-Original-URL header" || b.Technique == "X-Rewrite-URL header" {
+			t.Errorf("home page reported for %s: %d", b.Technique, b.Status)
+		}
+	}
+}
+
+func TestCheckBypassRewriteHonored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Original-URL") == "/app/secret" {
+			w.Write([]byte("the secret"))
+			return
+		}
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte("host home page"))
+		case "/app/secret":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	s := newTestScanner(t, server.URL+"/app", &Config{Bypass: true})
+	result, err := s.fetchWithOptions(server.URL+"/app/secret", requestOptions{NoFollow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.checkBypass(result)
+	found := false
+	for _, b := range result.Bypasses {
+		if b.Technique == "X-Original-URL header" && b.Status == 200 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("X-Original-URL bypass not reported: %+v", result.Bypasses)
+	}
+}
+
+func TestCheckBypassStatusChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/admin" && r.Method == "HEAD":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/admin" && r.Header.Get("X-Forwarded-For") == "127.0.0.1":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/admin/":
+			http.Redirect(w, r, "/login", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	s := newTestScanner(t, server.URL, &Config{Bypass: true})
+	result, err := s.fetchWithOptions(server.URL+"/admin", requestOptions{NoFollow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.checkBypass(result)
+
+	want := map[string]Bypass{
+		"method HEAD":                {Status: 200, Confirmed: true},
+		"X-Forwarded-For: 127.0.0.1": {Status: 500},
+		"path: trailing slash":       {Status: 302, Location: "/login"},
+	}
+	if len(result.Bypasses) != len(want) {
+		t.Errorf("got %d status changes, want %d: %+v", len(result.Bypasses), len(want), result.Bypasses)
+	}
+	for _, b := range result.Bypasses {
+		w, ok := want[b.Technique]
+		if !ok {
+			t.Errorf("unexpected status change %s -> %d", b.Technique, b.Status)
+			continue
+		}
+		if b.Status != w.Status || b.Confirmed != w.Confirmed || b.Location != w.Location {
+			t.Errorf("%s: got status %d confirmed %v location %q, want %d %v %q",
+				b.Technique, b.Status, b.Confirmed, b.Location, w.Status, w.Confirmed, w.Location)
+		}
+	}
+	if confirmed := result.ConfirmedBypasses(); len(confirmed) != 1 || confirmed[0].Technique != "method HEAD" {
+		t.Errorf("ConfirmedBypasses() = %+v, want only method HEAD", confirmed)
+	}
+}
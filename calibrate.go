@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ===========================================================================
+// AUTO-CALIBRATION (-ac)
+// ===========================================================================
+
+// calibrationClass is one kind of nonsense request. Each probe gets a
+// different random length so a reflected path shows up as unstable size.
+type calibrationClass struct {
+	Name  string
+	Paths func() []string
+}
+
+var calibrationClasses = []calibrationClass{
+	{"random words", func() []string {
+		return []string{randomString(8), randomString(12), randomString(16)}
+	}},
+	{"random extensions", func() []string {
+		return []string{randomString(8) + ".php", randomString(12) + ".aspx", randomString(10) + "." + randomString(4)}
+	}},
+	{"deep paths", func() []string {
+		return []string{
+			randomString(6) + "/" + randomString(8),
+			randomString(8) + "/" + randomString(6) + "/" + randomString(10),
+			randomString(10) + "/" + randomString(4) + "/",
+		}
+	}},
+	{"dot paths", func() []string {
+		return []string{"." + randomString(8), ".ht" + randomString(10), randomString(6) + ".." + randomString(6)}
+	}},
+}
+
+// CalibrationRule drops results that look like the server's answer to
+// nonsense: same status and the same value for one response property.
+type CalibrationRule struct {
+	Class   string // Probe class that produced the rule
+	Status  int
+	Field   string // "size", "words", "lines", "redirect" or "status"
+	Value   string
+	Enabled bool
+
+	hits int64 // Results dropped by this rule
+}
+
+// Hits returns how many results the rule has filtered
+func (r *CalibrationRule) Hits() int64 {
+	return atomic.LoadInt64(&r.hits)
+}
+
+func (r *CalibrationRule) String() string {
+	if r.Field == "status" {
+		return fmt.Sprintf("status %d", r.Status)
+	}
+	return fmt.Sprintf("status %d, %s %s", r.Status, r.Field, r.Value)
+}
+
+// Matches reports whether a result has the rule's status and field value
+func (r *CalibrationRule) Matches(result *ScanResult) bool {
+	if result.FinalStatus != r.Status {
+		return false
+	}
+	switch r.Field {
+	case "size":
+		return fmt.Sprint(result.ContentLength) == r.Value
+	case "words":
+		return fmt.Sprint(result.Words) == r.Value
+	case "lines":
+		return fmt.Sprint(result.Lines) == r.Value
+	case "redirect":
+		return len(result.RedirectChain) > 0 && redirectPattern(result) == r.Value
+	case "status":
+		return true
+	}
+	return false
+}
+
+// redirectPattern is the redirect target with the requested path taken out,
+// so /abc -> /abc/ and /xyz -> /xyz/ count as the same target
+func redirectPattern(result *ScanResult) string {
+	u, err := url.Parse(result.FinalURL)
+	if err != nil {
+		return result.FinalURL
+	}
+	target := u.Path
+	if p := strings.Trim(result.OriginalPath, "/"); p != "" {
+		target = strings.ReplaceAll(target, p, "{path}")
+	}
+	return u.Host + target
+}
+
+// countWords and countLines measure a body the way ffuf does, so rules
+// survive a reflected path changing the byte size
+func countWords(body []byte) int {
+	return len(bytes.Fields(body))
+}
+
+func countLines(body []byte) int {
+	if len(body) == 0 {
+		return 0
+	}
+	return bytes.Count(body, []byte("\n")) + 1
+}
+
+// AutoCalibration holds the rules derived at scan start. Rules can be
+// switched off one by one from the F4 menu while the scan runs.
+type AutoCalibration struct {
+	mu    sync.Mutex
+	Rules []*CalibrationRule
+}
+
+// Snapshot returns the current rules for display
+func (ac *AutoCalibration) Snapshot() []*CalibrationRule {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return append([]*CalibrationRule(nil), ac.Rules...)
+}
+
+// Toggle flips a rule on or off
+func (ac *AutoCalibration) Toggle(i int) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if i >= 0 && i < len(ac.Rules) {
+		ac.Rules[i].Enabled = !ac.Rules[i].Enabled
+	}
+}
+
+// Filter reports whether an enabled rule matches the result
+func (ac *AutoCalibration) Filter(result *ScanResult) bool {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	for _, rule := range ac.Rules {
+		if rule.Enabled && rule.Matches(result) {
+			atomic.AddInt64(&rule.hits, 1)
+			return true
+		}
+	}
+	return false
+}
+
+// AutoCalibrate sends each class of nonsense request and turns whatever the
+// responses have in common into filter rules. Per class, the most specific
+// stable property wins: size, then words, then lines. A shared redirect
+// target gets its own rule. A status alone is only used for 404 and 410:
+// for anything else (a 403 on every dot path, a catch-all 200) it would
+// drop every real result with that status too.
+func (s *Scanner) AutoCalibrate() *AutoCalibration {
+	ac := &AutoCalibration{}
+	seen := make(map[string]bool)
+	add := func(rule *CalibrationRule) {
+		key := rule.String()
+		if !seen[key] {
+			seen[key] = true
+			rule.Enabled = true
+			ac.Rules = append(ac.Rules, rule)
+		}
+	}
+
+	for _, class := range calibrationClasses {
+		// A class can answer with more than one status (/.ht* 403, /a..b
+		// 200) - each status with two or more responses gets its own rule
+		byStatus := make(map[int][]*ScanResult)
+		var statuses []int
+		for _, p := range class.Paths() {
+			result, err := s.FetchWithRedirectTracking(s.BaseURL + "/" + p)
+			if err != nil {
+				continue
+			}
+			if len(byStatus[result.FinalStatus]) == 0 {
+				statuses = append(statuses, result.FinalStatus)
+			}
+			byStatus[result.FinalStatus] = append(byStatus[result.FinalStatus], result)
+		}
+
+		for _, status := range statuses {
+			if rule := calibrationRule(class.Name, byStatus[status]); rule != nil {
+				add(rule)
+			}
+		}
+	}
+	return ac
+}
+
+// calibrationRule derives one rule from same-status responses to a class,
+// or nil if they have nothing usable in common
+func calibrationRule(class string, responses []*ScanResult) *CalibrationRule {
+	if len(responses) < 2 {
+		return nil
+	}
+
+	first := responses[0]
+	sameSize, sameWords, sameLines, sameRedirect := true, true, true, len(first.RedirectChain) > 0
+	for _, r := range responses[1:] {
+		sameSize = sameSize && r.ContentLength == first.ContentLength
+		sameWords = sameWords && r.Words == first.Words
+		sameLines = sameLines && r.Lines == first.Lines
+		sameRedirect = sameRedirect && len(r.RedirectChain) > 0 && redirectPattern(r) == redirectPattern(first)
+	}
+
+	rule := &CalibrationRule{Class: class, Status: first.FinalStatus}
+	switch {
+	case sameRedirect:
+		rule.Field, rule.Value = "redirect", redirectPattern(first)
+	case sameSize:
+		rule.Field, rule.Value = "size", fmt.Sprint(first.ContentLength)
+	case sameWords:
+		rule.Field, rule.Value = "words", fmt.Sprint(first.Words)
+	case sameLines:
+		rule.Field, rule.Value = "lines", fmt.Sprint(first.Lines)
+	case first.FinalStatus == 404 || first.FinalStatus == 410:
+		rule.Field = "status"
+	default:
+		return nil
+	}
+	return rule
+}
+
+// calibrationFiltered applies the -ac rules, if calibration is on
+func (s *Scanner) calibrationFiltered(result *ScanResult) bool {
+	if !s.Config.AutoCalibrate || s.calibration == nil {
+		return false
+	}
+	return s.calibration.Filter(result)
+}
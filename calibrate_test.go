@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+)
+
+func calibrationResponse(status, size, words, lines int) *ScanResult {
+	return &ScanResult{FinalStatus: status, ContentLength: size, Words: words, Lines: lines}
+}
+
+func redirectResponse(path, finalURL string) *ScanResult {
+	return &ScanResult{
+		OriginalPath:  "/" + path,
+		FinalStatus:   200,
+		FinalURL:      finalURL,
+		RedirectChain: []RedirectStep{{Status: 301}},
+	}
+}
+
+func TestCalibrationRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []*ScanResult
+		want      string // rule.String(), "" for no rule
+	}{
+		{"single response", []*ScanResult{calibrationResponse(404, 10, 2, 1)}, ""},
+		{"same size", []*ScanResult{calibrationResponse(200, 512, 40, 10), calibrationResponse(200, 512, 41, 11)}, "status 200, size 512"},
+		{"same words", []*ScanResult{calibrationResponse(200, 512, 40, 10), calibrationResponse(200, 520, 40, 11)}, "status 200, words 40"},
+		{"same lines", []*ScanResult{calibrationResponse(200, 512, 40, 10), calibrationResponse(200, 520, 42, 10)}, "status 200, lines 10"},
+		{"redirect first", []*ScanResult{
+			redirectResponse("abc", "http://example.com/abc/"),
+			redirectResponse("xyz", "http://example.com/xyz/"),
+		}, "status 200, redirect example.com/{path}/"},
+		{"different redirects", []*ScanResult{
+			redirectResponse("abc", "http://example.com/abc/"),
+			func() *ScanResult {
+				r := redirectResponse("xyz", "http://example.com/login")
+				r.ContentLength = 7
+				return r
+			}(),
+		}, "status 200, words 0"},
+		{"unstable 404", []*ScanResult{calibrationResponse(404, 100, 10, 1), calibrationResponse(404, 120, 12, 2)}, "status 404"},
+		{"unstable 410", []*ScanResult{calibrationResponse(410, 100, 10, 1), calibrationResponse(410, 120, 12, 2)}, "status 410"},
+		// A status alone would drop every real 403 or 500 on the site
+		{"unstable 403", []*ScanResult{calibrationResponse(403, 100, 10, 1), calibrationResponse(403, 120, 12, 2)}, ""},
+		{"unstable 500", []*ScanResult{calibrationResponse(500, 100, 10, 1), calibrationResponse(500, 120, 12, 2)}, ""},
+		{"unstable 200", []*ScanResult{calibrationResponse(200, 100, 10, 1), calibrationResponse(200, 120, 12, 2)}, ""},
+	}
+	for _, tt := range tests {
+		rule := calibrationRule("test", tt.responses)
+		got := ""
+		if rule != nil {
+			got = rule.String()
+		}
+		if got != tt.want {
+			t.Errorf("%s: rule %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCalibrationRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule   CalibrationRule
+		result *ScanResult
+		want   bool
+	}{
+		{CalibrationRule{Status: 200, Field: "size", Value: "512"}, calibrationResponse(200, 512, 1, 1), true},
+		{CalibrationRule{Status: 200, Field: "size", Value: "512"}, calibrationResponse(200, 513, 1, 1), false},
+		{CalibrationRule{Status: 200, Field: "size", Value: "512"}, calibrationResponse(403, 512, 1, 1), false},
+		{CalibrationRule{Status: 200, Field: "words", Value: "40"}, calibrationResponse(200, 1, 40, 1), true},
+		{CalibrationRule{Status: 200, Field: "lines", Value: "3"}, calibrationResponse(200, 1, 1, 4), false},
+		{CalibrationRule{Status: 404, Field: "status"}, calibrationResponse(404, 9, 9, 9), true},
+		{CalibrationRule{Status: 200, Field: "redirect", Value: "example.com/{path}/"}, redirectResponse("admin", "http://example.com/admin/"), true},
+		{CalibrationRule{Status: 200, Field: "redirect", Value: "example.com/{path}/"}, redirectResponse("admin", "http://example.com/login"), false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Matches(tt.result); got != tt.want {
+			t.Errorf("%s matches %+v = %v, want %v", tt.rule.String(), tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestAutoCalibrationFilter(t *testing.T) {
+	ac := &AutoCalibration{Rules: []*CalibrationRule{
+		{Status: 200, Field: "size", Value: "512", Enabled: true},
+		{Status: 404, Field: "status", Enabled: true},
+	}}
+	if !ac.Filter(calibrationResponse(200, 512, 1, 1)) || !ac.Filter(calibrationResponse(404, 1, 1, 1)) {
+		t.Fatal("enabled rules didn't filter")
+	}
+	if ac.Filter(calibrationResponse(200, 600, 1, 1)) {
+		t.Error("unmatched result filtered")
+	}
+
+	ac.Toggle(0)
+	if ac.Filter(calibrationResponse(200, 512, 1, 1)) {
+		t.Error("disabled rule still filters")
+	}
+	if hits := ac.Rules[0].Hits(); hits != 1 {
+		t.Errorf("rule 0 hits = %d, want 1", hits)
+	}
+}
+
+func TestCountWordsLines(t *testing.T) {
+	tests := []struct {
+		body         string
+		words, lines int
+	}{
+		{"", 0, 0},
+		{"one", 1, 1},
+		{"Not Found\n", 2, 2},
+		{"<p>a b</p>\n<p>c</p>", 3, 2},
+	}
+	for _, tt := range tests {
+		if words, lines := countWords([]byte(tt.body)), countLines([]byte(tt.body)); words != tt.words || lines != tt.lines {
+			t.Errorf("%q: %d words %d lines, want %d %d", tt.body, words, lines, tt.words, tt.lines)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"crypto/md5"
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// ===========================================================================
+// SIMILARITY CLUSTERING
+// ===========================================================================
+
+// MaxSimHashDistance is how many of the 64 SimHash bits two bodies may differ
+// in and still count as the same page (reflected paths, timestamps, CSRF
+// tokens and the like).
+const MaxSimHashDistance = 3
+
+var digitRunRegex = regexp.MustCompile(`[0-9]+`)
+
+// Cluster groups results with identical or near-identical content. The first
+// member is the representative shown for the whole cluster.
+type Cluster struct {
+	ID             int
+	Status         int
+	SimHash        uint64
+	NormHash       string
+	Representative *ScanResult
+	Members        []*ScanResult
+	Noise          bool
+
+	size int64 // len(Members), readable without the stats lock
+}
+
+// Size returns the member count; safe to call while the scan is running
+func (c *Cluster) Size() int {
+	return int(atomic.LoadInt64(&c.size))
+}
+
+// SimHash fingerprints a body so that pages differing in only a few words
+// land a few bits apart. Digits are folded together so counters, dates and
+// IDs don't make otherwise identical pages look different.
+func SimHash(body []byte) uint64 {
+	var weights [64]int
+	tokens := strings.FieldsFunc(strings.ToLower(string(body)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		token = strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return '0'
+			}
+			return r
+		}, token)
+
+		h := fnv.New64a()
+		h.Write([]byte(token))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash
+}
+
+// NormalizedHash hashes a body with the requested path and all digits taken
+// out, so short soft-404 pages that echo the path or a timestamp match even
+// when one changed token moves their SimHash too far.
+func NormalizedHash(body []byte, requestPath string) string {
+	content := string(body)
+	reflections := []string{requestPath, strings.TrimPrefix(requestPath, "/"), path.Base(requestPath)}
+	if unescaped, err := url.PathUnescape(requestPath); err == nil {
+		reflections = append(reflections, unescaped)
+	}
+	for _, r := range reflections {
+		// Stripping one or two bare letters would mangle the rest of the page
+		if len(r) >= 3 || (len(r) == 2 && r[0] == '/') {
+			content = strings.ReplaceAll(content, r, "")
+		}
+	}
+	content = digitRunRegex.ReplaceAllString(content, "0")
+	return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+}
+
+// clusterResult puts a result into the cluster it matches (same status, and
+// the same ContentHash, NormalizedHash or a SimHash within
+// MaxSimHashDistance), or starts a new one. Returns true if the cluster was
+// marked as noise, in which case the result should be dropped.
+func (s *Scanner) clusterResult(result *ScanResult) bool {
+	result.SimHash = SimHash(result.body)
+	normHash := NormalizedHash(result.body, result.OriginalPath)
+
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	var match *Cluster
+	for _, c := range s.Stats.Clusters {
+		if c.Status != result.FinalStatus {
+			continue
+		}
+		if c.Representative.ContentHash == result.ContentHash || c.NormHash == normHash ||
+			bits.OnesCount64(c.SimHash^result.SimHash) <= MaxSimHashDistance {
+			match = c
+			break
+		}
+	}
+
+	if match == nil {
+		match = &Cluster{
+			ID:             len(s.Stats.Clusters) + 1,
+			Status:         result.FinalStatus,
+			SimHash:        result.SimHash,
+			NormHash:       normHash,
+			Representative: result,
+		}
+		s.Stats.Clusters = append(s.Stats.Clusters, match)
+	}
+	if match.Noise {
+		return true
+	}
+
+	match.Members = append(match.Members, result)
+	atomic.AddInt64(&match.size, 1)
+	result.ClusterID = match.ID
+	result.cluster = match
+	return false
+}
+
+// withoutNoise returns the results whose cluster hasn't been marked as noise
+// since they were kept
+func (s *Scanner) withoutNoise(results []*ScanResult) []*ScanResult {
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	kept := make([]*ScanResult, 0, len(results))
+	for _, r := range results {
+		if r.cluster == nil || !r.cluster.Noise {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// MarkClusterNoise drops every member of a cluster from the results and
+// counters, and makes the scan drop anything that matches it from now on.
+// Returns how many results were removed.
+func (s *Scanner) MarkClusterNoise(c *Cluster) int {
+	s.Stats.mu.Lock()
+	if c.Noise {
+		s.Stats.mu.Unlock()
+		return 0
+	}
+	c.Noise = true
+	members := c.Members
+	c.Members = nil
+	atomic.StoreInt64(&c.size, 0)
+
+	drop := make(map[*ScanResult]bool, len(members))
+	for _, m := range members {
+		drop[m] = true
+	}
+	// Always a new slice: the TUI may still be walking the old one
+	keep := func(results []*ScanResult) []*ScanResult {
+		kept := make([]*ScanResult, 0, len(results))
+		for _, r := range results {
+			if !drop[r] {
+				kept = append(kept, r)
+			}
+		}
+		return kept
+	}
+
+	s.Stats.Direct200s = keep(s.Stats.Direct200s)
+	s.Stats.Redirect200s = keep(s.Stats.Redirect200s)
+	s.Stats.Redirects = keep(s.Stats.Redirects)
+	s.Stats.OtherCodes = keep(s.Stats.OtherCodes)
+	s.Stats.Sensitive = keep(s.Stats.Sensitive)
+	s.Stats.Backups = keep(s.Stats.Backups)
+	s.Stats.Listings = keep(s.Stats.Listings)
+	s.Stats.OpenRedirects = keep(s.Stats.OpenRedirects)
+	s.Stats.Bypasses = keep(s.Stats.Bypasses)
+	for hash, results := range s.Stats.ContentHashes {
+		if results = keep(results); len(results) == 0 {
+			delete(s.Stats.ContentHashes, hash)
+		} else {
+			s.Stats.ContentHashes[hash] = results
+		}
+	}
+
+	// Undo what ScanPath counted for each member
+	for _, m := range members {
+		s.Stats.TotalScanned--
+		if m.IsDirect200 {
+			atomic.AddInt64(&s.LiveStats.Direct200s, -1)
+		} else if len(m.RedirectChain) > 0 {
+			if m.FinalStatus == 200 {
+				atomic.AddInt64(&s.LiveStats.Redirect200s, -1)
+			}
+			s.Stats.RedirectTargets[m.FinalURL]--
+			if s.Stats.RedirectTargets[m.FinalURL] <= 0 {
+				delete(s.Stats.RedirectTargets, m.FinalURL)
+			}
+			atomic.AddInt64(&s.LiveStats.Redirects, -1)
+		}
+		if m.FinalStatus == 401 || m.FinalStatus == 403 {
+			atomic.AddInt64(&s.LiveStats.Protected, -1)
+		}
+	}
+	s.Stats.mu.Unlock()
+
+	s.resultsMutex.Lock()
+	s.lastResults = keep(s.lastResults)
+	s.resultsMutex.Unlock()
+
+	return len(members)
+}
+
+// NoiseClusters returns how many clusters are marked as noise
+func (s *Scanner) NoiseClusters() int {
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	count := 0
+	for _, c := range s.Stats.Clusters {
+		if c.Noise {
+			count++
+		}
+	}
+	return count
+}
+
+// SortedClusters returns clusters with more than one member, largest first
+func (s *Scanner) SortedClusters() []*Cluster {
+	s.Stats.mu.Lock()
+	var sorted []*Cluster
+	for _, c := range s.Stats.Clusters {
+		if !c.Noise && len(c.Members) > 1 {
+			sorted = append(sorted, c)
+		}
+	}
+	s.Stats.mu.Unlock()
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Size() > sorted[j].Size()
+	})
+	return sorted
+}
+
+// collapseClusters keeps one result per cluster (the first in the list)
+func collapseClusters(results []*ScanResult) []*ScanResult {
+	seen := make(map[*Cluster]bool)
+	var collapsed []*ScanResult
+	for _, r := range results {
+		if r.cluster != nil {
+			if seen[r.cluster] {
+				continue
+			}
+			seen[r.cluster] = true
+		}
+		collapsed = append(collapsed, r)
+	}
+	return collapsed
+}
+
+// clusterTag is the live view's member count for collapsed results
+func clusterTag(result *ScanResult) string {
+	if result.cluster == nil {
+		return ""
+	}
+	if size := result.cluster.Size(); size > 1 {
+		return fmt.Sprintf("[x%d similar]", size)
+	}
+	return ""
+}
+
+// clusterReportLine is the report line pointing at a result's duplicates
+func clusterReportLine(result *ScanResult) string {
+	if result.cluster == nil || result.cluster.Size() < 2 {
+		return ""
+	}
+	return fmt.Sprintf("    Similar:     %d more responses (cluster #%d)\n", result.cluster.Size()-1, result.ClusterID)
+}
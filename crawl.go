@@ -0,0 +1,160 @@
+package main
+
+import (
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// ===========================================================================
+// LINK EXTRACTION & CRAWLING
+// ===========================================================================
+
+// MaxCrawlPaths caps how many paths crawling and content extraction can queue
+// in one scan, so sites that generate endless unique URLs can't keep a scan
+// running forever.
+const MaxCrawlPaths = 10000
+
+var (
+	linkAttrRegex    = regexp.MustCompile(`(?is)\b(?:href|src|action|data-src|formaction)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'<>]+))`)
+	baseHrefRegex    = regexp.MustCompile(`(?is)<base\s[^>]*href\s*=\s*["']([^"']+)["']`)
+	metaRefreshRegex = regexp.MustCompile(`(?is)<meta[^>]+http-equiv\s*=\s*["']?refresh["']?[^>]*>`)
+	refreshURLRegex  = regexp.MustCompile(`(?is)content\s*=\s*["'][^"']*?url\s*=\s*['"]?([^"'>\s]+)`)
+
+	// Assets that can't lead anywhere new - not worth a request each
+	crawlSkipExts = map[string]bool{
+		".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true,
+		".ico": true, ".bmp": true, ".svg": true, ".woff": true, ".woff2": true,
+		".ttf": true, ".eot": true, ".otf": true, ".mp3": true, ".mp4": true,
+		".webm": true, ".avi": true, ".mov": true,
+	}
+)
+
+// ExtractLinks pulls href, src, action and meta refresh targets out of an
+// HTML body, resolved against the page URL (or its <base href>).
+func ExtractLinks(body []byte, pageURL string) []string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	content := string(body)
+	if m := baseHrefRegex.FindStringSubmatch(content); m != nil {
+		if b, err := base.Parse(html.UnescapeString(m[1])); err == nil {
+			base = b
+		}
+	}
+
+	var raw []string
+	for _, m := range linkAttrRegex.FindAllStringSubmatch(content, -1) {
+		raw = append(raw, m[1]+m[2]+m[3])
+	}
+	for _, tag := range metaRefreshRegex.FindAllString(content, -1) {
+		if m := refreshURLRegex.FindStringSubmatch(tag); m != nil {
+			raw = append(raw, m[1])
+		}
+	}
+
+	seen := make(map[string]bool)
+	var links []string
+	for _, link := range raw {
+		link = strings.TrimSpace(html.UnescapeString(link))
+		if link == "" || strings.HasPrefix(link, "#") {
+			continue
+		}
+		lower := strings.ToLower(link)
+		if strings.HasPrefix(lower, "mailto:") || strings.HasPrefix(lower, "javascript:") ||
+			strings.HasPrefix(lower, "data:") || strings.HasPrefix(lower, "tel:") {
+			continue
+		}
+
+		resolved, err := base.Parse(link)
+		if err != nil {
+			continue
+		}
+		resolved.Fragment = ""
+		abs := resolved.String()
+		if !seen[abs] {
+			seen[abs] = true
+			links = append(links, abs)
+		}
+	}
+	return links
+}
+
+// ScopePath turns an absolute URL into a scan path relative to BaseURL.
+// Returns false for anything on another host or outside the base path.
+func (s *Scanner) ScopePath(rawURL string) (string, bool) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	base, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return "", false
+	}
+
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return "", false
+	}
+	if !strings.EqualFold(target.Hostname(), base.Hostname()) || target.Port() != base.Port() {
+		return "", false
+	}
+
+	basePath := strings.TrimSuffix(base.Path, "/")
+	targetPath := target.EscapedPath()
+	if basePath != "" && targetPath != basePath && !strings.HasPrefix(targetPath, basePath+"/") {
+		return "", false
+	}
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(targetPath, basePath), "/")
+	if rel == "" {
+		return "", false
+	}
+	if crawlSkipExts[strings.ToLower(path.Ext(rel))] {
+		return "", false
+	}
+	return rel, true
+}
+
+// crawlResult feeds links from a result back into the scan queue: HTML links
+// from 200 pages, plus every Location hop of a redirect chain.
+func (s *Scanner) crawlResult(result *ScanResult) {
+	var candidates []string
+
+	// Each hop's target is the next hop's URL, the last one is FinalURL
+	for i := 1; i < len(result.RedirectChain); i++ {
+		candidates = append(candidates, result.RedirectChain[i].URL)
+	}
+	if len(result.RedirectChain) > 0 {
+		candidates = append(candidates, result.FinalURL)
+	}
+
+	if result.FinalStatus == 200 && len(result.body) > 0 && isHTMLContent(result.ContentType, result.body) {
+		candidates = append(candidates, ExtractLinks(result.body, result.FinalURL)...)
+	}
+
+	s.queueDiscovered(candidates, SourceCrawl)
+}
+
+// queueDiscovered scopes absolute URLs and queues the new ones under source
+func (s *Scanner) queueDiscovered(urls []string, source string) int {
+	queued := 0
+	for _, u := range urls {
+		if atomic.LoadInt64(&s.crawlQueued) >= MaxCrawlPaths {
+			break
+		}
+		rel, ok := s.ScopePath(u)
+		if !ok {
+			continue
+		}
+		if s.EnqueuePath(rel, source) {
+			atomic.AddInt64(&s.crawlQueued, 1)
+			queued++
+		}
+	}
+	return queued
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ===========================================================================
+// DIRECTORY DETECTION
+// ===========================================================================
+
+// PathKind is what a result turned out to be, based on response evidence
+type PathKind string
+
+const (
+	KindUnknown   PathKind = ""
+	KindFile      PathKind = "file"
+	KindDirectory PathKind = "dir"
+)
+
+// isRecursionCandidate reports whether a status is worth classifying for
+// recursion: content, redirects (often directories) and 403s (might have
+// accessible children).
+func isRecursionCandidate(status int) bool {
+	switch status {
+	case 200, 301, 302, 403:
+		return true
+	}
+	return false
+}
+
+// ClassifyResult decides file vs directory from what the response itself
+// shows, without extra requests. Returns KindUnknown when there is no
+// evidence either way.
+func ClassifyResult(result *ScanResult) (PathKind, string) {
+	// /admin -> /admin/ is the server saying it's a directory. Each hop
+	// records the URL it came from, so the first hop's target is the next
+	// hop's URL (or the final URL for a single redirect).
+	if len(result.RedirectChain) > 0 {
+		firstTarget := result.FinalURL
+		if len(result.RedirectChain) > 1 {
+			firstTarget = result.RedirectChain[1].URL
+		}
+		if target, err := url.Parse(firstTarget); err == nil {
+			original := strings.TrimSuffix(result.OriginalPath, "/")
+			if original != "" && strings.HasSuffix(target.Path, original+"/") {
+				return KindDirectory, "trailing-slash redirect"
+			}
+		}
+	}
+
+	if result.Listing != "" {
+		return KindDirectory, "directory listing"
+	}
+
+	// Anything served as a download or a non-document type is a file
+	if result.header != nil {
+		if disposition := result.header.Get("Content-Disposition"); strings.HasPrefix(strings.ToLower(disposition), "attachment") {
+			return KindFile, "Content-Disposition attachment"
+		}
+	}
+	if result.FinalStatus == 200 && result.ContentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+		if isFileMediaType(mediaType) {
+			return KindFile, "Content-Type " + mediaType
+		}
+	}
+
+	return KindUnknown, ""
+}
+
+// isFileMediaType reports whether a Content-Type is only ever a file.
+// HTML, JSON and XML are left out - routes and API collections serve those
+// and can have children.
+func isFileMediaType(mediaType string) bool {
+	switch {
+	case mediaType == "", mediaType == "text/html", mediaType == "application/xhtml+xml",
+		mediaType == "application/json", strings.HasSuffix(mediaType, "+json"),
+		mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		return false
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "audio/"),
+		strings.HasPrefix(mediaType, "video/"), strings.HasPrefix(mediaType, "font/"):
+		return true
+	case strings.HasPrefix(mediaType, "text/"), strings.HasPrefix(mediaType, "application/"):
+		return true
+	}
+	return false
+}
+
+// classifyResult marks a result as file or directory. Only recursion uses the
+// answer, so extra requests are only sent when -r is on and the result could
+// be recursed into (-depth, -exclude, -recurse-include, -recurse-status).
+// Then anything that might be a directory is probed: with a trailing slash if
+// the response alone doesn't settle it, then with a random child compared
+// against the result itself. A child that answers 200 becomes that directory's own
+// wildcard baseline so its catch-all page isn't reported for every word.
+func (s *Scanner) classifyResult(result *ScanResult) {
+	kind, evidence := ClassifyResult(result)
+	if kind != KindFile && s.recursionEnabled() && isRecursionCandidate(result.FinalStatus) && s.recursionAllowed(result) {
+		kind, evidence = s.probeDirectory(result, kind, evidence)
+	}
+	result.Kind = kind
+	result.KindEvidence = evidence
+}
+
+func (s *Scanner) probeDirectory(result *ScanResult, kind PathKind, evidence string) (PathKind, string) {
+	dirPath := strings.Trim(result.OriginalPath, "/")
+	if dirPath == "" {
+		return KindDirectory, "base path"
+	}
+	dirURL := s.BaseURL + "/" + dirPath
+
+	// Files 404 with a trailing slash on almost every server
+	if kind == KindUnknown && !strings.HasSuffix(result.OriginalPath, "/") {
+		slash, err := s.FetchWithRedirectTracking(dirURL + "/")
+		if err != nil {
+			return KindUnknown, ""
+		}
+		if slash.FinalStatus == 404 || slash.FinalStatus == 410 {
+			return KindFile, "404 with trailing slash"
+		}
+		if isRecursionCandidate(slash.FinalStatus) &&
+			(slash.FinalStatus != result.FinalStatus || slash.ContentHash != result.ContentHash) {
+			kind, evidence = KindDirectory, "responds with trailing slash"
+		}
+	}
+
+	child, err := s.FetchWithRedirectTracking(dirURL + "/" + randomString(16))
+	if err != nil {
+		return kind, evidence
+	}
+
+	// Servers that hand the rest of the path to a script (index.php/anything)
+	// answer every child with the file itself - recursing there is pointless
+	if kind == KindUnknown && child.FinalStatus == result.FinalStatus && child.ContentHash == result.ContentHash {
+		return KindFile, "children return the same response"
+	}
+
+	if child.FinalStatus == 200 {
+		s.pathMutex.Lock()
+		s.dirBaselines[visitKey(dirPath)] = &WildcardBaseline{
+			Hash:   child.ContentHash,
+			Length: child.ContentLength,
+			Status: child.FinalStatus,
+		}
+		s.pathMutex.Unlock()
+		if kind == KindUnknown {
+			return KindDirectory, "catch-all children (baseline recorded)"
+		}
+		return kind, evidence + ", catch-all children"
+	}
+
+	if kind == KindUnknown {
+		return KindDirectory, "children resolve independently"
+	}
+	return kind, evidence
+}
+
+// isDirectoryWildcard reports whether a result matches the catch-all
+// baseline recorded for the directory it lives in.
+func (s *Scanner) isDirectoryWildcard(result *ScanResult) bool {
+	dir := path.Dir(strings.Trim(result.OriginalPath, "/"))
+	if dir == "." {
+		return false
+	}
+
+	s.pathMutex.Lock()
+	baseline := s.dirBaselines[visitKey(dir)]
+	s.pathMutex.Unlock()
+
+	return baseline != nil && result.FinalStatus == baseline.Status && result.ContentHash == baseline.Hash
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ===========================================================================
+// TECHNOLOGY-AWARE EXTENSIONS (-auto-x)
+// ===========================================================================
+
+// Extensions worth trying once a technology is fingerprinted. Frameworks
+// that route without extensions (Rails, Django, Express...) pick nothing;
+// PHP CMSes and Tomcat/Jetty are covered through what they imply.
+var techExtensions = map[string][]string{
+	"PHP":           {"php"},
+	"ASP.NET":       {"aspx"},
+	"Microsoft IIS": {"asp", "aspx"},
+	"Java":          {"jsp", "do"},
+	"ColdFusion":    {"cfm"},
+}
+
+// Server-side extensions that, seen on a hit, say what the stack runs
+var dynamicExtensions = map[string]bool{
+	"php": true, "phtml": true, "asp": true, "aspx": true, "ashx": true, "asmx": true,
+	"jsp": true, "jspx": true, "do": true, "action": true, "cfm": true, "cgi": true,
+	"pl": true, "py": true, "rb": true,
+}
+
+// PickedExtension is an extension chosen for the scan and why
+type PickedExtension struct {
+	Ext      string
+	Evidence string // "PHP (cookie PHPSESSID)", "hit /login.aspx"...
+}
+
+type extensionInference struct {
+	mu     sync.Mutex
+	picked []PickedExtension
+}
+
+// pick records extensions not picked before and returns the new ones
+func (e *extensionInference) pick(exts []string, evidence string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var added []string
+	for _, ext := range exts {
+		known := false
+		for _, p := range e.picked {
+			if p.Ext == ext {
+				known = true
+				break
+			}
+		}
+		if !known {
+			e.picked = append(e.picked, PickedExtension{Ext: ext, Evidence: evidence})
+			added = append(added, ext)
+		}
+	}
+	return added
+}
+
+// Picked returns the chosen extensions in the order they were picked
+func (e *extensionInference) Picked() []PickedExtension {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]PickedExtension(nil), e.picked...)
+}
+
+// inferExtensionsFromRoot fingerprints / before the wordlist is queued, so
+// an obvious stack (X-Powered-By, a session cookie) gets its extensions from
+// the first request on. Returns the extensions picked.
+func (s *Scanner) inferExtensionsFromRoot() []string {
+	s.extensions = &extensionInference{}
+	if root, err := s.FetchWithRedirectTracking(s.BaseURL + "/"); err == nil {
+		s.detectTechnologies(root)
+	}
+	return s.extensionsFromTechnologies()
+}
+
+// extensionsFromTechnologies picks extensions for every technology
+// fingerprinted so far and returns the new ones. Only technologies seen in a
+// header, cookie or 2xx body count; a URL or known-path match alone says
+// more about the wordlist than the stack.
+func (s *Scanner) extensionsFromTechnologies() []string {
+	var added []string
+	for _, tech := range s.SortedTechnologies() {
+		if tech.Confirmed == "" {
+			continue
+		}
+		if exts, ok := techExtensions[tech.Name]; ok {
+			evidence := tech.Name + " (" + tech.Confirmed + ")"
+			added = append(added, s.extensions.pick(exts, evidence)...)
+		}
+	}
+	return added
+}
+
+// inferExtensions runs on every kept result. New technologies and hits with a
+// server-side extension can pick more extensions mid-scan; those are applied
+// to the whole wordlist from then on (words already requested with them are
+// skipped by the visited set).
+func (s *Scanner) inferExtensions(result *ScanResult) {
+	if s.extensions == nil {
+		return
+	}
+
+	added := s.extensionsFromTechnologies()
+	if result.FinalStatus >= 200 && result.FinalStatus < 300 {
+		p := strings.SplitN(result.OriginalPath, "?", 2)[0]
+		ext := strings.ToLower(strings.TrimPrefix(path.Ext(p), "."))
+		if dynamicExtensions[ext] {
+			added = append(added, s.extensions.pick([]string{ext}, "hit /"+strings.TrimPrefix(p, "/"))...)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	for _, p := range extensionVariants(s.recursionWords, added) {
+		s.EnqueuePath(p, SourceWordlist)
+	}
+}
+
+// extensionVariants appends each extension to the words that don't already
+// end in one ("admin" -> "admin.php", "robots.txt" stays as it is)
+func extensionVariants(words, exts []string) []string {
+	var variants []string
+	for _, word := range words {
+		last := word[strings.LastIndex(word, "/")+1:]
+		if last == "" || path.Ext(last) != "" {
+			continue
+		}
+		for _, ext := range exts {
+			variants = append(variants, word+"."+ext)
+		}
+	}
+	return variants
+}
+
+// PickedExtensions returns the -auto-x extensions of the current scan
+func (s *Scanner) PickedExtensions() []PickedExtension {
+	if s.extensions == nil {
+		return nil
+	}
+	return s.extensions.Picked()
+}
+
+// extensionsSummary is the dashboard line listing the extensions in use:
+// -x ones as given, picked ones marked auto
+func (s *Scanner) extensionsSummary() string {
+	var parts []string
+	for _, ext := range s.Config.Extensions {
+		parts = append(parts, "."+strings.TrimPrefix(ext, "."))
+	}
+	var picked []string
+	for _, p := range s.PickedExtensions() {
+		picked = append(picked, "."+p.Ext)
+	}
+	sort.Strings(picked)
+	if len(picked) > 0 {
+		parts = append(parts, fmt.Sprintf("%s (auto)", strings.Join(picked, " ")))
+	} else if s.Config.AutoExtensions {
+		parts = append(parts, "auto: none yet")
+	}
+	return "Extensions: " + strings.Join(parts, " ")
+}
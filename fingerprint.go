@@ -0,0 +1,469 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ===========================================================================
+// TECHNOLOGY FINGERPRINTING
+// ===========================================================================
+
+//go:embed signatures/technologies.json
+var embeddedSignatures []byte
+
+// techPattern is one compiled Wappalyzer-style pattern. The source string may
+// carry a version template after "\;version:", e.g. "nginx/([\d.]+)\;version:\1".
+type techPattern struct {
+	re      *regexp.Regexp
+	version string
+}
+
+type TechSignature struct {
+	Name       string
+	Categories []string
+	Headers    map[string][]techPattern // keyed by canonical header name
+	Cookies    map[string]techPattern   // cookie name, trailing * matches a prefix
+	HTML       []techPattern
+	Meta       map[string]techPattern // keyed by lowercase meta name
+	ScriptSrc  []techPattern
+	URL        []techPattern
+	Paths      []techPattern // matched against the path of hits only
+	Implies    []string
+}
+
+// DetectedTech is a technology seen somewhere on the target during a scan.
+type DetectedTech struct {
+	Name       string
+	Version    string
+	Categories []string
+	Evidence   string // first thing that matched, e.g. "header Server"
+	Confirmed  string // first header, cookie or 2xx body match ("" if only URLs or paths matched)
+	FoundOn    string // path of the response that matched first
+	Hits       int
+}
+
+type TechDetector struct {
+	Signatures []*TechSignature
+	byName     map[string]*TechSignature
+}
+
+type rawTechSignature struct {
+	Cats      []string          `json:"cats"`
+	Headers   map[string]string `json:"headers"`
+	Cookies   map[string]string `json:"cookies"`
+	HTML      stringOrList      `json:"html"`
+	Meta      map[string]string `json:"meta"`
+	ScriptSrc stringOrList      `json:"scriptSrc"`
+	URL       stringOrList      `json:"url"`
+	Paths     stringOrList      `json:"paths"`
+	Implies   stringOrList      `json:"implies"`
+}
+
+// stringOrList accepts both "pattern" and ["pattern", ...] like Wappalyzer does
+type stringOrList []string
+
+func (s *stringOrList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+var (
+	defaultTechDetector     *TechDetector
+	defaultTechDetectorErr  error
+	defaultTechDetectorOnce sync.Once
+
+	metaTagRegex   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaNameRegex  = regexp.MustCompile(`(?is)\b(?:name|property|http-equiv)\s*=\s*["']([^"']+)["']`)
+	metaValueRegex = regexp.MustCompile(`(?is)\bcontent\s*=\s*["']([^"']*)["']`)
+	scriptSrcRegex = regexp.MustCompile(`(?is)<script[^>]+\bsrc\s*=\s*["']([^"']+)["']`)
+)
+
+// DefaultTechDetector returns the detector built from the embedded signature set
+func DefaultTechDetector() (*TechDetector, error) {
+	defaultTechDetectorOnce.Do(func() {
+		defaultTechDetector, defaultTechDetectorErr = ParseTechSignatures(embeddedSignatures)
+	})
+	return defaultTechDetector, defaultTechDetectorErr
+}
+
+// ParseTechSignatures compiles a Wappalyzer-style JSON document. Both the
+// {"technologies": {...}} wrapper and a bare name->signature object work.
+func ParseTechSignatures(data []byte) (*TechDetector, error) {
+	var wrapped struct {
+		Technologies map[string]rawTechSignature `json:"technologies"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, err
+	}
+	raw := wrapped.Technologies
+	if raw == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	}
+
+	detector := &TechDetector{byName: make(map[string]*TechSignature)}
+	for name, r := range raw {
+		sig := &TechSignature{
+			Name:       name,
+			Categories: r.Cats,
+			Headers:    make(map[string][]techPattern),
+			Cookies:    make(map[string]techPattern),
+			Meta:       make(map[string]techPattern),
+			Implies:    r.Implies,
+		}
+
+		var err error
+		for header, pattern := range r.Headers {
+			p, perr := compileTechPattern(pattern)
+			if perr != nil {
+				return nil, fmt.Errorf("%s: header %s: %v", name, header, perr)
+			}
+			key := http.CanonicalHeaderKey(header)
+			sig.Headers[key] = append(sig.Headers[key], p)
+		}
+		for cookie, pattern := range r.Cookies {
+			p, perr := compileTechPattern(pattern)
+			if perr != nil {
+				return nil, fmt.Errorf("%s: cookie %s: %v", name, cookie, perr)
+			}
+			sig.Cookies[cookie] = p
+		}
+		for meta, pattern := range r.Meta {
+			p, perr := compileTechPattern(pattern)
+			if perr != nil {
+				return nil, fmt.Errorf("%s: meta %s: %v", name, meta, perr)
+			}
+			sig.Meta[strings.ToLower(meta)] = p
+		}
+		if sig.HTML, err = compileTechPatterns(r.HTML); err != nil {
+			return nil, fmt.Errorf("%s: html: %v", name, err)
+		}
+		if sig.ScriptSrc, err = compileTechPatterns(r.ScriptSrc); err != nil {
+			return nil, fmt.Errorf("%s: scriptSrc: %v", name, err)
+		}
+		if sig.URL, err = compileTechPatterns(r.URL); err != nil {
+			return nil, fmt.Errorf("%s: url: %v", name, err)
+		}
+		if sig.Paths, err = compileTechPatterns(r.Paths); err != nil {
+			return nil, fmt.Errorf("%s: paths: %v", name, err)
+		}
+
+		detector.Signatures = append(detector.Signatures, sig)
+		detector.byName[name] = sig
+	}
+
+	// Stable order so the first evidence recorded doesn't depend on map iteration
+	sort.Slice(detector.Signatures, func(i, j int) bool {
+		return detector.Signatures[i].Name < detector.Signatures[j].Name
+	})
+
+	return detector, nil
+}
+
+func compileTechPattern(source string) (techPattern, error) {
+	parts := strings.Split(source, `\;`)
+	p := techPattern{}
+	for _, extra := range parts[1:] {
+		if strings.HasPrefix(extra, "version:") {
+			p.version = strings.TrimPrefix(extra, "version:")
+		}
+	}
+	re, err := regexp.Compile("(?i)" + parts[0])
+	if err != nil {
+		return p, err
+	}
+	p.re = re
+	return p, nil
+}
+
+func compileTechPatterns(sources []string) ([]techPattern, error) {
+	var patterns []techPattern
+	for _, src := range sources {
+		p, err := compileTechPattern(src)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// match reports whether value matches and fills in the version template if any
+func (p techPattern) match(value string) (bool, string) {
+	groups := p.re.FindStringSubmatch(value)
+	if groups == nil {
+		return false, ""
+	}
+	if p.version == "" {
+		return true, ""
+	}
+	version := p.version
+	for i := len(groups) - 1; i >= 1; i-- {
+		version = strings.ReplaceAll(version, fmt.Sprintf(`\%d`, i), groups[i])
+	}
+	return true, strings.TrimSpace(version)
+}
+
+// TechMatch is a single detection produced from one response
+type TechMatch struct {
+	Name     string
+	Version  string
+	Evidence string
+}
+
+// Detect runs every signature against one response. URL and path patterns
+// are only checked when isHit is set, since a 404 for /wp-admin or
+// /config.php proves nothing.
+func (d *TechDetector) Detect(path, finalURL string, header http.Header, body []byte, isHit bool) []TechMatch {
+	var matches []TechMatch
+	found := make(map[string]int)
+
+	record := func(name, version, evidence string) {
+		if idx, ok := found[name]; ok {
+			if matches[idx].Version == "" && version != "" {
+				matches[idx].Version = version
+			}
+			return
+		}
+		found[name] = len(matches)
+		matches = append(matches, TechMatch{Name: name, Version: version, Evidence: evidence})
+	}
+
+	cookies := parseSetCookieNames(header)
+	html := string(body)
+
+	var metas map[string]string
+	var scripts []string
+	if len(body) > 0 {
+		metas = extractMetaTags(html)
+		for _, m := range scriptSrcRegex.FindAllStringSubmatch(html, -1) {
+			scripts = append(scripts, m[1])
+		}
+	}
+
+	for _, sig := range d.Signatures {
+		for name, patterns := range sig.Headers {
+			values, ok := header[name]
+			if !ok {
+				continue
+			}
+			for _, p := range patterns {
+				for _, v := range values {
+					if ok, version := p.match(v); ok {
+						record(sig.Name, version, "header "+name)
+					}
+				}
+			}
+		}
+
+		for name, p := range sig.Cookies {
+			for cookieName, value := range cookies {
+				if !cookieNameMatches(name, cookieName) {
+					continue
+				}
+				if ok, version := p.match(value); ok {
+					record(sig.Name, version, "cookie "+cookieName)
+				}
+			}
+		}
+
+		for name, p := range sig.Meta {
+			if value, ok := metas[name]; ok {
+				if ok, version := p.match(value); ok {
+					record(sig.Name, version, "meta "+name)
+				}
+			}
+		}
+
+		for _, p := range sig.ScriptSrc {
+			for _, src := range scripts {
+				if ok, version := p.match(src); ok {
+					record(sig.Name, version, "script "+truncateString(src, 60))
+				}
+			}
+		}
+
+		if html != "" {
+			for _, p := range sig.HTML {
+				if ok, version := p.match(html); ok {
+					record(sig.Name, version, "body pattern")
+				}
+			}
+		}
+
+		if isHit {
+			for _, p := range sig.URL {
+				if ok, version := p.match(finalURL); ok {
+					record(sig.Name, version, "url")
+				}
+			}
+			for _, p := range sig.Paths {
+				if ok, version := p.match(path); ok {
+					record(sig.Name, version, "known path")
+				}
+			}
+		}
+	}
+
+	// Resolve implied technologies (PHP from WordPress and so on)
+	for i := 0; i < len(matches); i++ {
+		sig := d.byName[matches[i].Name]
+		if sig == nil {
+			continue
+		}
+		for _, implied := range sig.Implies {
+			record(implied, "", "implied by "+sig.Name)
+		}
+	}
+
+	return matches
+}
+
+// Categories returns the categories of a known technology
+func (d *TechDetector) Categories(name string) []string {
+	if sig := d.byName[name]; sig != nil {
+		return sig.Categories
+	}
+	return nil
+}
+
+func cookieNameMatches(pattern, name string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(strings.ToLower(name), strings.ToLower(strings.TrimSuffix(pattern, "*")))
+	}
+	return strings.EqualFold(pattern, name)
+}
+
+func parseSetCookieNames(header http.Header) map[string]string {
+	cookies := make(map[string]string)
+	for _, line := range header.Values("Set-Cookie") {
+		pair := strings.SplitN(line, ";", 2)[0]
+		kv := strings.SplitN(pair, "=", 2)
+		name := strings.TrimSpace(kv[0])
+		if name == "" {
+			continue
+		}
+		value := ""
+		if len(kv) == 2 {
+			value = strings.TrimSpace(kv[1])
+		}
+		cookies[name] = value
+	}
+	return cookies
+}
+
+func extractMetaTags(html string) map[string]string {
+	metas := make(map[string]string)
+	for _, tag := range metaTagRegex.FindAllString(html, -1) {
+		name := metaNameRegex.FindStringSubmatch(tag)
+		value := metaValueRegex.FindStringSubmatch(tag)
+		if name == nil || value == nil {
+			continue
+		}
+		metas[strings.ToLower(name[1])] = value[1]
+	}
+	return metas
+}
+
+// detectTechnologies fingerprints a kept result and merges what it finds into
+// the scan statistics.
+func (s *Scanner) detectTechnologies(result *ScanResult) {
+	detector, err := DefaultTechDetector()
+	if err != nil || detector == nil {
+		return
+	}
+
+	isHit := result.FinalStatus == 200
+	matches := detector.Detect(result.OriginalPath, result.FinalURL, result.header, result.body, isHit)
+	if len(matches) == 0 {
+		return
+	}
+
+	// Implied technologies come after what implies them, so one pass
+	// settles which matches the response itself vouches for
+	is2xx := result.FinalStatus >= 200 && result.FinalStatus < 300
+	confirmed := make(map[string]string)
+	for _, m := range matches {
+		if techEvidenceConfirms(m.Evidence, is2xx) {
+			confirmed[m.Name] = m.Evidence
+		} else if by, ok := strings.CutPrefix(m.Evidence, "implied by "); ok && confirmed[by] != "" {
+			confirmed[m.Name] = m.Evidence
+		}
+	}
+
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	for _, m := range matches {
+		tech, ok := s.Stats.Technologies[m.Name]
+		if !ok {
+			tech = &DetectedTech{
+				Name:       m.Name,
+				Version:    m.Version,
+				Categories: detector.Categories(m.Name),
+				Evidence:   m.Evidence,
+				FoundOn:    result.OriginalPath,
+			}
+			s.Stats.Technologies[m.Name] = tech
+		}
+		if tech.Version == "" && m.Version != "" {
+			tech.Version = m.Version
+		}
+		if tech.Confirmed == "" {
+			tech.Confirmed = confirmed[m.Name]
+		}
+		tech.Hits++
+	}
+}
+
+// techEvidenceConfirms reports whether a match stands on its own: a header
+// or cookie always does, page content only on a 2xx. A URL or known path
+// only says which paths were requested.
+func techEvidenceConfirms(evidence string, is2xx bool) bool {
+	switch {
+	case strings.HasPrefix(evidence, "header "), strings.HasPrefix(evidence, "cookie "):
+		return true
+	case strings.HasPrefix(evidence, "meta "), strings.HasPrefix(evidence, "script "), evidence == "body pattern":
+		return is2xx
+	}
+	return false
+}
+
+// SortedTechnologies returns detected technologies ordered by name
+func (s *Scanner) SortedTechnologies() []*DetectedTech {
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	techs := make([]*DetectedTech, 0, len(s.Stats.Technologies))
+	for _, t := range s.Stats.Technologies {
+		techs = append(techs, t)
+	}
+	sort.Slice(techs, func(i, j int) bool {
+		return techs[i].Name < techs[j].Name
+	})
+	return techs
+}
+
+func (t *DetectedTech) Label() string {
+	if t.Version != "" {
+		return t.Name + " " + t.Version
+	}
+	return t.Name
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testSignatures = `{"technologies": {
+	"Nginx": {"cats": ["Web servers"], "headers": {"server": "nginx(?:/([\\d.]+))?\\;version:\\1"}},
+	"WordPress": {
+		"cats": ["CMS"],
+		"meta": {"generator": "^WordPress ?([\\d.]+)?\\;version:\\1"},
+		"html": "<link[^>]+/wp-content/",
+		"paths": "^/?wp-(?:admin|login\\.php)",
+		"implies": ["PHP", "MySQL"]
+	},
+	"WooCommerce": {"cats": ["Ecommerce"], "scriptSrc": "woocommerce(?:\\.min)?\\.js(?:\\?ver=([\\d.]+))?\\;version:\\1", "implies": "WordPress"},
+	"PHP": {"cats": ["Programming languages"], "cookies": {"PHPSESSID": ""}, "url": "\\.php(?:$|\\?)"},
+	"MySQL": {"cats": ["Databases"]},
+	"Laravel": {"cats": ["Web frameworks"], "cookies": {"laravel_*": ""}, "implies": "PHP"}
+}}`
+
+func TestParseTechSignatures(t *testing.T) {
+	d, err := ParseTechSignatures([]byte(testSignatures))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Signatures) != 6 {
+		t.Errorf("got %d signatures, want 6", len(d.Signatures))
+	}
+	if got := d.Categories("WordPress"); !reflect.DeepEqual(got, []string{"CMS"}) {
+		t.Errorf("Categories(WordPress) = %q", got)
+	}
+
+	// The bare name->signature form works too
+	bare, err := ParseTechSignatures([]byte(`{"Nginx": {"headers": {"Server": "nginx"}}}`))
+	if err != nil || len(bare.Signatures) != 1 || bare.Signatures[0].Headers["Server"] == nil {
+		t.Errorf("bare document: %+v, %v", bare, err)
+	}
+
+	if _, err := ParseTechSignatures([]byte(`{"Broken": {"html": "(unclosed"}}`)); err == nil {
+		t.Error("invalid regex accepted")
+	}
+	if _, err := DefaultTechDetector(); err != nil {
+		t.Errorf("embedded signatures: %v", err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	d, err := ParseTechSignatures([]byte(testSignatures))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		url    string
+		header http.Header
+		body   string
+		isHit  bool
+		want   []TechMatch
+	}{
+		{
+			name:   "header version template",
+			header: http.Header{"Server": {"nginx/1.25.3"}},
+			want:   []TechMatch{{Name: "Nginx", Version: "1.25.3", Evidence: "header Server"}},
+		},
+		{
+			name:   "header without a version",
+			header: http.Header{"Server": {"nginx"}},
+			want:   []TechMatch{{Name: "Nginx", Evidence: "header Server"}},
+		},
+		{
+			name: "meta version and implies",
+			body: `<meta name="generator" content="WordPress 6.4.2">`,
+			want: []TechMatch{
+				{Name: "WordPress", Version: "6.4.2", Evidence: "meta generator"},
+				{Name: "PHP", Evidence: "implied by WordPress"},
+				{Name: "MySQL", Evidence: "implied by WordPress"},
+			},
+		},
+		{
+			name: "implies chain",
+			body: `<script src="/js/woocommerce.min.js?ver=8.5.1"></script>`,
+			want: []TechMatch{
+				{Name: "WooCommerce", Version: "8.5.1", Evidence: "script /js/woocommerce.min.js?ver=8.5.1"},
+				{Name: "WordPress", Evidence: "implied by WooCommerce"},
+				{Name: "PHP", Evidence: "implied by WordPress"},
+				{Name: "MySQL", Evidence: "implied by WordPress"},
+			},
+		},
+		{
+			name:   "exact cookie",
+			header: http.Header{"Set-Cookie": {"PHPSESSID=abc123; path=/"}},
+			want:   []TechMatch{{Name: "PHP", Evidence: "cookie PHPSESSID"}},
+		},
+		{
+			name:   "cookie prefix",
+			header: http.Header{"Set-Cookie": {"Laravel_Session=xyz; HttpOnly"}},
+			want: []TechMatch{
+				{Name: "Laravel", Evidence: "cookie Laravel_Session"},
+				{Name: "PHP", Evidence: "implied by Laravel"},
+			},
+		},
+		{
+			name:  "url and path on a hit",
+			path:  "/wp-login.php",
+			url:   "http://example.com/wp-login.php",
+			isHit: true,
+			want: []TechMatch{
+				{Name: "PHP", Evidence: "url"},
+				{Name: "WordPress", Evidence: "known path"},
+				{Name: "MySQL", Evidence: "implied by WordPress"},
+			},
+		},
+		{
+			name: "url and path ignored on a miss",
+			path: "/wp-login.php",
+			url:  "http://example.com/wp-login.php",
+		},
+		{
+			name:   "no match",
+			header: http.Header{"Server": {"Apache/2.4.58"}, "Set-Cookie": {"session=1"}},
+			body:   `<html><head><meta name="generator" content="Hugo 0.120"></head></html>`,
+		},
+	}
+	for _, tt := range tests {
+		header := tt.header
+		if header == nil {
+			header = http.Header{}
+		}
+		got := d.Detect(tt.path, tt.url, header, []byte(tt.body), tt.isHit)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCookieNameMatches(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"PHPSESSID", "phpsessid", true},
+		{"PHPSESSID", "PHPSESSID2", false},
+		{"wp-settings-*", "wp-settings-time-1", true},
+		{"wp-settings-*", "wp-setting", false},
+	}
+	for _, tt := range tests {
+		if got := cookieNameMatches(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("cookieNameMatches(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ===========================================================================
+// PAYLOAD GENERATORS (-wordlist "invoice/{range:1-5000}")
+// ===========================================================================
+
+// A generator is a path template with {kind:args} placeholders, accepted
+// anywhere a wordlist file is. A bare "kind:args" is the whole path.
+//
+//	invoice/{range:1-5000}                      invoice/1 .. invoice/5000
+//	{range:0-1000,step=10,pad=4}                0000 0010 .. 1000
+//	backup/{dates:2024-01-01..2024-12-31}.zip   backup/2024-01-01.zip ..
+//	{dates:2020-01-01..2024-12-01,step=1m,format=YYYYMM}
+//	chars:a-z0-9,max=3                          a b .. 999
+//	{range:1-12,pad=2}/{range:1-31,pad=2}       every combination
+//
+// Options after the first argument:
+//
+//	range  FROM-TO      step=N, pad=WIDTH (a zero-padded FROM like 0001 pads too)
+//	dates  FROM..TO     step=Nd, Nw, Nm or Ny (default 1d), format using
+//	                    YYYY YY MM DD (default YYYY-MM-DD)
+//	chars  CHARSET      max=LENGTH, min=LENGTH (default 1); a-z style ranges
+//
+// Paths are produced one at a time as they are needed, so a range of
+// millions costs no more memory than a range of ten.
+
+const (
+	// MaxGeneratedPaths rejects specs that could never finish (and keeps
+	// counts clear of overflow)
+	MaxGeneratedPaths = 10_000_000_000
+
+	// MaxLoadedGeneratedPaths caps generators loaded into memory by the
+	// wordlist subcommands; "wordlist gen" streams any size
+	MaxLoadedGeneratedPaths = 5_000_000
+
+	// generatorBacklog is how many generated paths may wait in the scan
+	// queue before the generator pauses for the workers to catch up
+	generatorBacklog = 4096
+)
+
+var (
+	generatorPlaceholderRegex = regexp.MustCompile(`\{(range|dates|chars):([^{}]*)\}`)
+	generatorBareRegex        = regexp.MustCompile(`^(range|dates|chars):`)
+)
+
+// payloadSource is the value sequence of one placeholder
+type payloadSource interface {
+	Count() int64
+	// Each calls yield with every value in order, stopping early (and
+	// returning false) when yield returns false
+	Each(yield func(string) bool) bool
+}
+
+// PathGenerator is a parsed generator spec
+type PathGenerator struct {
+	Spec  string
+	parts []generatorPart
+	count int64
+}
+
+// generatorPart is a literal followed by an optional placeholder
+type generatorPart struct {
+	literal string
+	source  payloadSource
+}
+
+// IsGeneratorSpec reports whether a -wordlist value is a generator rather
+// than a file name
+func IsGeneratorSpec(spec string) bool {
+	return generatorBareRegex.MatchString(spec) || generatorPlaceholderRegex.MatchString(spec)
+}
+
+// ParseGenerator parses a generator spec
+func ParseGenerator(spec string) (*PathGenerator, error) {
+	template := spec
+	if generatorBareRegex.MatchString(spec) {
+		template = "{" + spec + "}"
+	}
+
+	g := &PathGenerator{Spec: spec, count: 1}
+	last := 0
+	for _, m := range generatorPlaceholderRegex.FindAllStringSubmatchIndex(template, -1) {
+		kind, args := template[m[2]:m[3]], template[m[4]:m[5]]
+		source, err := parsePayloadSource(kind, args)
+		if err != nil {
+			return nil, fmt.Errorf("{%s:%s}: %v", kind, args, err)
+		}
+		if source.Count() == 0 {
+			return nil, fmt.Errorf("{%s:%s}: produces nothing", kind, args)
+		}
+		if g.count > MaxGeneratedPaths/source.Count() {
+			return nil, fmt.Errorf("%s: more than %d paths", spec, int64(MaxGeneratedPaths))
+		}
+		g.count *= source.Count()
+		g.parts = append(g.parts, generatorPart{literal: template[last:m[0]], source: source})
+		last = m[1]
+	}
+	if len(g.parts) == 0 {
+		return nil, fmt.Errorf("%s: no {range:...}, {dates:...} or {chars:...} placeholder", spec)
+	}
+	g.parts = append(g.parts, generatorPart{literal: template[last:]})
+	return g, nil
+}
+
+// Count is how many paths the generator produces
+func (g *PathGenerator) Count() int64 {
+	return g.count
+}
+
+// Each calls yield with every path, the last placeholder varying fastest.
+// Stops early when yield returns false.
+func (g *PathGenerator) Each(yield func(string) bool) {
+	g.each(0, "", yield)
+}
+
+func (g *PathGenerator) each(i int, prefix string, yield func(string) bool) bool {
+	part := g.parts[i]
+	prefix += part.literal
+	if part.source == nil {
+		return yield(prefix)
+	}
+	return part.source.Each(func(value string) bool {
+		return g.each(i+1, prefix+value, yield)
+	})
+}
+
+// Paths loads every generated path, for the places that need the whole
+// list at once
+func (g *PathGenerator) Paths() ([]string, error) {
+	if g.count > MaxLoadedGeneratedPaths {
+		return nil, fmt.Errorf("%s: %d paths is too many to load, use \"pathfinder wordlist gen\" to write them to a file", g.Spec, g.count)
+	}
+	paths := make([]string, 0, g.count)
+	g.Each(func(p string) bool {
+		paths = append(paths, p)
+		return true
+	})
+	return paths, nil
+}
+
+// parsePayloadSource parses "ARG,key=value,..." for one placeholder kind
+func parsePayloadSource(kind, args string) (payloadSource, error) {
+	fields := strings.Split(args, ",")
+	options := make(map[string]string)
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("bad option %q (want key=value)", field)
+		}
+		options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	value := strings.TrimSpace(fields[0])
+
+	var source payloadSource
+	var err error
+	var known []string
+	switch kind {
+	case "range":
+		source, err = parseNumberRange(value, options)
+		known = []string{"step", "pad"}
+	case "dates":
+		source, err = parseDateRange(value, options)
+		known = []string{"step", "format"}
+	case "chars":
+		source, err = parseCharsetRange(value, options)
+		known = []string{"min", "max"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for key := range options {
+		if !containsString(known, key) {
+			return nil, fmt.Errorf("unknown option %q (have: %s)", key, strings.Join(known, ", "))
+		}
+	}
+	return source, nil
+}
+
+// optionInt reads a positive integer option, or def when it's not set
+func optionInt(options map[string]string, key string, def int64) (int64, error) {
+	value, ok := options[key]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("bad %s %q", key, value)
+	}
+	return n, nil
+}
+
+// numberRange is {range:FROM-TO}
+type numberRange struct {
+	from, to, step int64
+	pad            int
+}
+
+func parseNumberRange(value string, options map[string]string) (*numberRange, error) {
+	low, high, ok := strings.Cut(value, "-")
+	from, err1 := strconv.ParseInt(low, 10, 64)
+	to, err2 := strconv.ParseInt(high, 10, 64)
+	if !ok || err1 != nil || err2 != nil || from < 0 || to < from {
+		return nil, fmt.Errorf("bad range %q (want FROM-TO)", value)
+	}
+	r := &numberRange{from: from, to: to}
+	if r.step, err1 = optionInt(options, "step", 1); err1 != nil {
+		return nil, err1
+	}
+	pad, err := optionInt(options, "pad", 0)
+	if err != nil {
+		return nil, err
+	}
+	r.pad = int(pad)
+	if _, set := options["pad"]; !set && len(low) > 1 && low[0] == '0' {
+		r.pad = len(low)
+	}
+	return r, nil
+}
+
+func (r *numberRange) Count() int64 {
+	return (r.to-r.from)/r.step + 1
+}
+
+func (r *numberRange) Each(yield func(string) bool) bool {
+	for n := r.from; n <= r.to; n += r.step {
+		if !yield(fmt.Sprintf("%0*d", r.pad, n)) {
+			return false
+		}
+	}
+	return true
+}
+
+// dateRange is {dates:FROM..TO}
+type dateRange struct {
+	from, to            time.Time
+	years, months, days int
+	format              string
+	count               int64
+}
+
+func parseDateRange(value string, options map[string]string) (*dateRange, error) {
+	low, high, ok := strings.Cut(value, "..")
+	from, err1 := time.Parse("2006-01-02", strings.TrimSpace(low))
+	to, err2 := time.Parse("2006-01-02", strings.TrimSpace(high))
+	if !ok || err1 != nil || err2 != nil || to.Before(from) {
+		return nil, fmt.Errorf("bad dates %q (want YYYY-MM-DD..YYYY-MM-DD)", value)
+	}
+	r := &dateRange{from: from, to: to, days: 1, format: "YYYY-MM-DD"}
+
+	if step, set := options["step"]; set {
+		n, err := strconv.Atoi(strings.TrimRight(step, "dwmy"))
+		unit := strings.TrimLeft(step, "0123456789")
+		if err != nil || n <= 0 || len(unit) != 1 {
+			return nil, fmt.Errorf("bad step %q (want Nd, Nw, Nm or Ny)", step)
+		}
+		r.days = 0
+		switch unit {
+		case "d":
+			r.days = n
+		case "w":
+			r.days = 7 * n
+		case "m":
+			r.months = n
+		case "y":
+			r.years = n
+		}
+	}
+	if format, set := options["format"]; set {
+		if format == "" {
+			return nil, fmt.Errorf("empty format")
+		}
+		r.format = format
+	}
+
+	// Walking the range is cheap next to scanning it, and exact for
+	// month steps
+	for t := r.from; !t.After(r.to); t = r.at(r.count) {
+		r.count++
+	}
+	return r, nil
+}
+
+// at is the n-th date, computed from the start so month steps from the
+// 31st don't drift
+func (r *dateRange) at(n int64) time.Time {
+	k := int(n)
+	return r.from.AddDate(r.years*k, r.months*k, r.days*k)
+}
+
+func (r *dateRange) Count() int64 {
+	return r.count
+}
+
+func (r *dateRange) Each(yield func(string) bool) bool {
+	for n := int64(0); n < r.count; n++ {
+		if !yield(formatDate(r.at(n), r.format)) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDate fills YYYY, YY, MM and DD in a format; everything else is
+// kept as written
+func formatDate(t time.Time, format string) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); {
+		switch {
+		case strings.HasPrefix(format[i:], "YYYY"):
+			fmt.Fprintf(&sb, "%04d", t.Year())
+			i += 4
+		case strings.HasPrefix(format[i:], "YY"):
+			fmt.Fprintf(&sb, "%02d", t.Year()%100)
+			i += 2
+		case strings.HasPrefix(format[i:], "MM"):
+			fmt.Fprintf(&sb, "%02d", int(t.Month()))
+			i += 2
+		case strings.HasPrefix(format[i:], "DD"):
+			fmt.Fprintf(&sb, "%02d", t.Day())
+			i += 2
+		default:
+			sb.WriteByte(format[i])
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// charsetRange is {chars:CHARSET,max=N}: every string over the charset,
+// shortest first
+type charsetRange struct {
+	chars    []rune
+	min, max int
+	count    int64
+}
+
+func parseCharsetRange(value string, options map[string]string) (*charsetRange, error) {
+	chars := expandCharset(value)
+	if len(chars) == 0 {
+		return nil, fmt.Errorf("empty charset")
+	}
+	if _, set := options["max"]; !set {
+		return nil, fmt.Errorf("chars needs max=LENGTH")
+	}
+	max, err := optionInt(options, "max", 0)
+	if err != nil {
+		return nil, err
+	}
+	min, err := optionInt(options, "min", 1)
+	if err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, fmt.Errorf("min %d is over max %d", min, max)
+	}
+
+	r := &charsetRange{chars: chars, min: int(min), max: int(max)}
+	n := int64(len(chars))
+	for length, size := 1, int64(1); length <= r.max; length++ {
+		if size > MaxGeneratedPaths/n {
+			return nil, fmt.Errorf("more than %d paths", int64(MaxGeneratedPaths))
+		}
+		size *= n
+		if length >= r.min {
+			r.count += size
+		}
+	}
+	return r, nil
+}
+
+// expandCharset turns "a-z0-9_" into its characters, without duplicates
+func expandCharset(spec string) []rune {
+	runes := []rune(spec)
+	var chars []rune
+	seen := make(map[rune]bool)
+	add := func(r rune) {
+		if !seen[r] {
+			seen[r] = true
+			chars = append(chars, r)
+		}
+	}
+	for i := 0; i < len(runes); i++ {
+		if i+2 < len(runes) && runes[i+1] == '-' && runes[i] <= runes[i+2] {
+			for r := runes[i]; r <= runes[i+2]; r++ {
+				add(r)
+			}
+			i += 2
+			continue
+		}
+		add(runes[i])
+	}
+	return chars
+}
+
+func (r *charsetRange) Count() int64 {
+	return r.count
+}
+
+func (r *charsetRange) Each(yield func(string) bool) bool {
+	for length := r.min; length <= r.max; length++ {
+		// Odometer over the charset, last position turning fastest
+		digits := make([]int, length)
+		word := make([]rune, length)
+		for {
+			for i, d := range digits {
+				word[i] = r.chars[d]
+			}
+			if !yield(string(word)) {
+				return false
+			}
+			i := length - 1
+			for ; i >= 0; i-- {
+				digits[i]++
+				if digits[i] < len(r.chars) {
+					break
+				}
+				digits[i] = 0
+			}
+			if i < 0 {
+				break
+			}
+		}
+	}
+	return true
+}
+
+// streamGenerator feeds a -wordlist generator onto the running scan's
+// queue from a goroutine, pausing whenever generatorBacklog paths are
+// waiting. The queue is held open until the generator runs out or the scan
+// is cancelled, so workers don't stop while it catches up. Generated paths
+// are distinct by construction and are only checked against the visited
+// set, never added to it, which would otherwise grow with the range.
+func (s *Scanner) streamGenerator(g *PathGenerator) {
+	queue := s.queue
+	exts := s.Config.Extensions
+	total := g.Count() * int64(1+len(exts))
+	atomic.AddInt64(&s.LiveStats.TotalRequests, total)
+
+	queue.Hold()
+	go func() {
+		defer queue.Release()
+
+		var fed int64
+		g.Each(func(word string) bool {
+			s.cancelMutex.Lock()
+			cancelled := s.cancelScan
+			s.cancelMutex.Unlock()
+			if cancelled {
+				return false
+			}
+
+			queue.WaitBelow(generatorBacklog)
+			for _, p := range GeneratePathsWithExtensions([]string{word}, exts) {
+				fed++
+				if s.isExcluded(p) || s.isVisited(p) {
+					atomic.AddInt64(&s.LiveStats.TotalRequests, -1)
+					continue
+				}
+				queue.Push(QueuedPath{Path: p, Source: SourceWordlist})
+			}
+			return true
+		})
+
+		// Paths never generated after a cancel count as done, like the
+		// ones dropped from the queue
+		if remaining := total - fed; remaining > 0 {
+			atomic.AddInt64(&s.LiveStats.CompletedRequests, remaining)
+		}
+	}()
+}
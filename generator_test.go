@@ -0,0 +1,105 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGenerator(t *testing.T) {
+	tests := []struct {
+		spec  string
+		count int64
+		first []string
+		last  string
+	}{
+		{"invoice/{range:1-5000}", 5000, []string{"invoice/1", "invoice/2"}, "invoice/5000"},
+		{"range:1-3", 3, []string{"1", "2", "3"}, "3"},
+		{"{range:0-1000,step=10,pad=4}", 101, []string{"0000", "0010"}, "1000"},
+		{"{range:0001-12,step=5}", 3, []string{"0001", "0006"}, "0011"},
+		{"backup/{dates:2024-01-01..2024-12-31}.zip", 366, []string{"backup/2024-01-01.zip", "backup/2024-01-02.zip"}, "backup/2024-12-31.zip"},
+		{"{dates:2024-01-01..2024-03-01,step=1m,format=YYYYMM}", 3, []string{"202401", "202402", "202403"}, "202403"},
+		{"{dates:2024-02-28..2024-03-01,format=DD.MM.YY}", 3, []string{"28.02.24", "29.02.24"}, "01.03.24"},
+		{"{dates:2024-01-01..2024-01-31,step=2w}", 3, []string{"2024-01-01", "2024-01-15", "2024-01-29"}, "2024-01-29"},
+		{"chars:a-c,max=2", 12, []string{"a", "b", "c", "aa", "ab"}, "cc"},
+		{"{chars:ab,min=2,max=2}", 4, []string{"aa", "ab", "ba", "bb"}, "bb"},
+		{"{range:1-12,pad=2}/{range:1-31,pad=2}", 372, []string{"01/01", "01/02"}, "12/31"},
+	}
+	for _, tt := range tests {
+		g, err := ParseGenerator(tt.spec)
+		if err != nil {
+			t.Errorf("ParseGenerator(%q): %v", tt.spec, err)
+			continue
+		}
+		if g.Count() != tt.count {
+			t.Errorf("%q: Count() = %d, want %d", tt.spec, g.Count(), tt.count)
+		}
+		paths, err := g.Paths()
+		if err != nil {
+			t.Errorf("%q: Paths(): %v", tt.spec, err)
+			continue
+		}
+		if int64(len(paths)) != tt.count {
+			t.Errorf("%q: produced %d paths, Count() says %d", tt.spec, len(paths), tt.count)
+			continue
+		}
+		if !reflect.DeepEqual(paths[:len(tt.first)], tt.first) {
+			t.Errorf("%q: starts %q, want %q", tt.spec, paths[:len(tt.first)], tt.first)
+		}
+		if paths[len(paths)-1] != tt.last {
+			t.Errorf("%q: ends %q, want %q", tt.spec, paths[len(paths)-1], tt.last)
+		}
+	}
+}
+
+func TestParseGeneratorErrors(t *testing.T) {
+	for _, spec := range []string{
+		"plain/path",
+		"{range:5-1}",
+		"{range:x}",
+		"{range:1-3,step=0}",
+		"{range:1-3,step=x}",
+		"{range:1-3,bogus=1}",
+		"{range:1-3,pad}",
+		"{dates:2024-01-03..2024-01-01}",
+		"{dates:2024-01-01..2024-01-03,step=1q}",
+		"{dates:2024-01-01..2024-01-03,format=}",
+		"{chars:abc}",
+		"{chars:abc,min=3,max=2}",
+		"chars:a-z0-9,max=7",
+	} {
+		if _, err := ParseGenerator(spec); err == nil {
+			t.Errorf("ParseGenerator(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestIsGeneratorSpec(t *testing.T) {
+	tests := map[string]bool{
+		"wordlist.txt":                     false,
+		"C:\\lists\\words.txt":             false,
+		"{unknown:1-10}":                   false,
+		"range:1-10":                       true,
+		"id/{range:1-10}":                  true,
+		"d/{dates:2024-01-01..2024-01-02}": true,
+	}
+	for spec, want := range tests {
+		if got := IsGeneratorSpec(spec); got != want {
+			t.Errorf("IsGeneratorSpec(%q) = %v, want %v", spec, got, want)
+		}
+	}
+}
+
+func TestGeneratorEachStops(t *testing.T) {
+	g, err := ParseGenerator("chars:a-z0-9,max=6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := 0
+	g.Each(func(string) bool {
+		seen++
+		return seen < 10
+	})
+	if seen != 10 {
+		t.Errorf("Each kept going after yield returned false: %d calls", seen)
+	}
+}
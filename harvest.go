@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ===========================================================================
+// WORD HARVESTING (-harvest)
+// ===========================================================================
+
+const SourceHarvest = "harvest"
+
+const (
+	// Words shorter or longer than this are never path names
+	MinHarvestLength = 3
+	MaxHarvestLength = 32
+
+	// MaxHarvestWords caps the distinct words kept per scan
+	MaxHarvestWords = 20000
+)
+
+// Where a word was seen. A word in a title or heading says more about how
+// the target names things than one in a JS variable.
+const (
+	HarvestTitle   = "title"
+	HarvestHeading = "heading"
+	HarvestID      = "id"
+	HarvestJS      = "js"
+)
+
+var harvestWeights = map[string]int{
+	HarvestTitle:   4,
+	HarvestHeading: 3,
+	HarvestID:      2,
+	HarvestJS:      1,
+}
+
+var (
+	harvestHeadingRegex = regexp.MustCompile(`(?is)<h[1-6][^>]*>(.*?)</h[1-6]>`)
+	// id="user-profile", name="search_query", for="...", data-section="..."
+	harvestAttrRegex   = regexp.MustCompile(`(?i)\s(?:id|name|for|data-[a-z-]+)\s*=\s*["']([^"'<>]{1,64})["']`)
+	harvestScriptRegex = regexp.MustCompile(`(?is)<script[^>]*>(.*?)</script>`)
+	// var userProfile, const API_BASE, function loadOrders, class InvoiceView
+	harvestJSRegex   = regexp.MustCompile(`\b(?:var|let|const|function|class)\s+([A-Za-z_$][\w$]*)`)
+	harvestTagRegex  = regexp.MustCompile(`<[^>]+>`)
+	harvestWordRegex = regexp.MustCompile(`[A-Za-z][A-Za-z0-9_-]*`)
+)
+
+// Words too common in any page or script to be worth a request
+var harvestStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "you": true, "your": true, "are": true,
+	"our": true, "this": true, "that": true, "from": true, "not": true, "all": true, "can": true,
+	"has": true, "have": true, "was": true, "will": true, "more": true, "any": true, "out": true,
+	"into": true, "about": true, "here": true, "there": true, "what": true, "when": true,
+	"how": true, "who": true, "why": true, "which": true, "than": true, "then": true,
+	"var": true, "let": true, "const": true, "function": true, "return": true, "true": true,
+	"false": true, "null": true, "undefined": true, "new": true, "typeof": true, "window": true,
+	"document": true, "else": true, "self": true, "arguments": true,
+	"div": true, "span": true, "nbsp": true, "amp": true, "quot": true, "http": true, "https": true,
+	"www": true, "com": true, "html": true,
+}
+
+// HarvestedWord is a candidate path word with its ranking
+type HarvestedWord struct {
+	Word    string
+	Score   int      // Sum of source weights over every page it was seen on
+	Pages   int      // Responses it appeared in
+	Sources []string // Kinds of places it was seen
+}
+
+type wordHarvester struct {
+	mu    sync.Mutex
+	words map[string]*HarvestedWord
+}
+
+func newWordHarvester() *wordHarvester {
+	return &wordHarvester{words: make(map[string]*HarvestedWord)}
+}
+
+// Add tokenizes one response. Each word counts once per page and source,
+// so a word repeated all over one page doesn't outrank one seen on many.
+func (h *wordHarvester) Add(result *ScanResult) {
+	found := make(map[string]map[string]bool) // word -> sources on this page
+	add := func(source, text string, keepCase bool) {
+		for _, token := range harvestWordRegex.FindAllString(text, -1) {
+			candidates := []string{token}
+			if parts := wordParts(token); len(parts) > 1 {
+				candidates = append(candidates, parts...)
+			}
+			for _, word := range candidates {
+				if !keepCase {
+					word = strings.ToLower(word)
+				}
+				if !usefulHarvestWord(word) {
+					continue
+				}
+				if found[word] == nil {
+					found[word] = make(map[string]bool)
+				}
+				found[word][source] = true
+			}
+		}
+	}
+
+	body := string(result.body)
+	if isJavaScriptResult(result) {
+		for _, m := range harvestJSRegex.FindAllStringSubmatch(body, -1) {
+			add(HarvestJS, m[1], true)
+		}
+	} else if isHTMLContent(result.ContentType, result.body) {
+		add(HarvestTitle, result.Title, false)
+		for _, m := range harvestHeadingRegex.FindAllStringSubmatch(body, -1) {
+			add(HarvestHeading, html.UnescapeString(harvestTagRegex.ReplaceAllString(m[1], " ")), false)
+		}
+		for _, m := range harvestAttrRegex.FindAllStringSubmatch(body, -1) {
+			add(HarvestID, m[1], true)
+		}
+		for _, script := range harvestScriptRegex.FindAllStringSubmatch(body, -1) {
+			for _, m := range harvestJSRegex.FindAllStringSubmatch(script[1], -1) {
+				add(HarvestJS, m[1], true)
+			}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for word, sources := range found {
+		entry, ok := h.words[word]
+		if !ok {
+			if len(h.words) >= MaxHarvestWords {
+				continue
+			}
+			entry = &HarvestedWord{Word: word}
+			h.words[word] = entry
+		}
+		entry.Pages++
+		for source := range sources {
+			entry.Score += harvestWeights[source]
+			if !containsString(entry.Sources, source) {
+				entry.Sources = append(entry.Sources, source)
+			}
+		}
+	}
+}
+
+// usefulHarvestWord drops stop words, numbers and tokens outside the length
+// bounds
+func usefulHarvestWord(word string) bool {
+	if len(word) < MinHarvestLength || len(word) > MaxHarvestLength {
+		return false
+	}
+	if strings.Trim(word, "0123456789_-") == "" {
+		return false
+	}
+	return !harvestStopWords[strings.ToLower(word)]
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Ranked returns every harvested word, highest score first
+func (h *wordHarvester) Ranked() []HarvestedWord {
+	h.mu.Lock()
+	ranked := make([]HarvestedWord, 0, len(h.words))
+	for _, entry := range h.words {
+		word := *entry
+		word.Sources = append([]string(nil), entry.Sources...)
+		sort.Strings(word.Sources)
+		ranked = append(ranked, word)
+	}
+	h.mu.Unlock()
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].Pages != ranked[j].Pages {
+			return ranked[i].Pages > ranked[j].Pages
+		}
+		return ranked[i].Word < ranked[j].Word
+	})
+	return ranked
+}
+
+// harvestResult feeds a successful response to the harvester
+func (s *Scanner) harvestResult(result *ScanResult) {
+	if s.harvest == nil || result.FinalStatus < 200 || result.FinalStatus >= 300 || len(result.body) == 0 {
+		return
+	}
+	s.harvest.Add(result)
+}
+
+// HarvestedWords returns the ranked words of the current scan (nil if
+// harvesting is off)
+func (s *Scanner) HarvestedWords() []HarvestedWord {
+	if s.harvest == nil {
+		return nil
+	}
+	return s.harvest.Ranked()
+}
+
+// queueHarvested pushes the top -harvest-top words (with -x extensions) for
+// a second pass. Words already requested are skipped by EnqueuePath.
+// Returns how many paths were queued.
+func (s *Scanner) queueHarvested() int {
+	ranked := s.HarvestedWords()
+	if s.Config.HarvestTop > 0 && len(ranked) > s.Config.HarvestTop {
+		ranked = ranked[:s.Config.HarvestTop]
+	}
+
+	words := make([]string, len(ranked))
+	for i, w := range ranked {
+		words[i] = w.Word
+	}
+	if len(s.Config.Extensions) > 0 {
+		words = GeneratePathsWithExtensions(words, s.Config.Extensions)
+	}
+
+	queued := 0
+	for _, word := range words {
+		if s.EnqueuePath(word, SourceHarvest) {
+			queued++
+		}
+	}
+	s.harvestQueued = queued
+	return queued
+}
+
+// harvestFilename expands {host} in -harvest-out to the target's host, so
+// each target scanned in a session gets its own wordlist
+func (s *Scanner) harvestFilename() string {
+	host := "target"
+	if u, err := url.Parse(s.BaseURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return strings.ReplaceAll(s.Config.HarvestOut, "{host}", host)
+}
+
+// saveHarvest writes the ranked words to -harvest-out, one per line, ready
+// to use as a -wordlist
+func (s *Scanner) saveHarvest() {
+	if s.Config.HarvestOut == "" || s.harvest == nil {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Words harvested from %s, ranked by score\n", s.BaseURL))
+	for _, w := range s.HarvestedWords() {
+		sb.WriteString(w.Word + "\n")
+	}
+
+	s.harvestFile = s.harvestFilename()
+	s.harvestErr = os.WriteFile(s.harvestFile, []byte(sb.String()), 0644)
+}
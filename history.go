@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ===========================================================================
+// HIT HISTORY (-history, -reorder)
+// ===========================================================================
+
+// HistoryVersion is bumped when the file format changes incompatibly
+const HistoryVersion = 1
+
+// Weights used to rank words by history. A hit counts more than a
+// protected path, and a hit on a target with the same stack counts more
+// again.
+const (
+	historyHitWeight       = 2
+	historyProtectedWeight = 1
+	historyTechWeight      = 3
+)
+
+// WordStats is what the history knows about one wordlist entry
+type WordStats struct {
+	Hits      int            `json:"hits"`                // 2xx results, direct or after a redirect
+	Protected int            `json:"protected,omitempty"` // 401/403 results
+	Tech      map[string]int `json:"tech,omitempty"`      // Hits on targets running each technology
+	LastSeen  time.Time      `json:"last_seen"`
+}
+
+// HitHistory is the local store of which words produced findings, kept
+// across scans and engagements
+type HitHistory struct {
+	Version int                   `json:"version"`
+	Scans   int                   `json:"scans"`
+	Words   map[string]*WordStats `json:"words"`
+}
+
+// DefaultHistoryFile is the suggested -history store and what "wordlist
+// sort" reads by default: the user's config directory, so every engagement
+// can share one store
+func DefaultHistoryFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pathfinder-history.json"
+	}
+	return filepath.Join(dir, "pathfinder", "history.json")
+}
+
+// LoadHitHistory reads a history file. A missing file is an empty history.
+func LoadHitHistory(filename string) (*HitHistory, error) {
+	h := &HitHistory{Version: HistoryVersion, Words: make(map[string]*WordStats)}
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	if h.Version != HistoryVersion {
+		return nil, fmt.Errorf("%s: unsupported history version %d", filename, h.Version)
+	}
+	if h.Words == nil {
+		h.Words = make(map[string]*WordStats)
+	}
+	return h, nil
+}
+
+// Save writes the history through a temporary file, so a crash mid-write
+// can't leave a truncated store behind
+func (h *HitHistory) Save(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// Score ranks a word for reordering. With techs, hits recorded on targets
+// running the same technologies weigh extra.
+func (h *HitHistory) Score(word string, techs []string) int {
+	stats, ok := h.Words[word]
+	if !ok {
+		return 0
+	}
+	score := stats.Hits*historyHitWeight + stats.Protected*historyProtectedWeight
+	for _, tech := range techs {
+		score += stats.Tech[tech] * historyTechWeight
+	}
+	return score
+}
+
+// Reorder moves words with a history to the front, best first. Words
+// without one keep their file order after them. Returns the reordered list
+// and how many words were moved up.
+func (h *HitHistory) Reorder(words []string, techs []string) ([]string, int) {
+	scores := make([]int, len(words))
+	known := 0
+	for i, word := range words {
+		scores[i] = h.Score(word, techs)
+		if scores[i] > 0 {
+			known++
+		}
+	}
+
+	order := make([]int, len(words))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	reordered := make([]string, len(words))
+	for i, idx := range order {
+		reordered[i] = words[idx]
+	}
+	return reordered, known
+}
+
+// historyWord maps a result back to the wordlist entry that produced it:
+// the path itself at the top level, the part after a recursed directory
+// below it, with an -x/-auto-x extension taken off.
+func historyWord(p string, words map[string]bool) string {
+	p = strings.Trim(strings.SplitN(p, "?", 2)[0], "/")
+	for candidate := p; candidate != ""; {
+		if words[candidate] {
+			return candidate
+		}
+		if ext := path.Ext(candidate); ext != "" && words[strings.TrimSuffix(candidate, ext)] {
+			return strings.TrimSuffix(candidate, ext)
+		}
+		i := strings.Index(candidate, "/")
+		if i < 0 {
+			break
+		}
+		candidate = candidate[i+1:]
+	}
+	return ""
+}
+
+// recordHistory adds this scan's wordlist findings to the -history store.
+// The file is re-read first, so scans running side by side don't overwrite
+// each other's counts.
+func (s *Scanner) recordHistory(results []*ScanResult) {
+	s.historyRecorded, s.historyErr = 0, nil
+	if s.Config.HistoryFile == "" {
+		return
+	}
+
+	words := make(map[string]bool, len(s.recursionWords))
+	for _, w := range s.recursionWords {
+		words[strings.Trim(w, "/")] = true
+	}
+	var techs []string
+	for _, tech := range s.SortedTechnologies() {
+		techs = append(techs, tech.Name)
+	}
+
+	history, err := LoadHitHistory(s.Config.HistoryFile)
+	if err != nil {
+		s.historyErr = err
+		return
+	}
+
+	now := time.Now()
+	counted := make(map[string]bool)
+	for _, result := range results {
+		if result.Source != SourceWordlist {
+			continue
+		}
+		hit := result.FinalStatus >= 200 && result.FinalStatus < 300
+		protected := result.FinalStatus == 401 || result.FinalStatus == 403
+		if !hit && !protected {
+			continue
+		}
+		word := historyWord(result.OriginalPath, words)
+		if word == "" || counted[word] {
+			continue
+		}
+		counted[word] = true
+
+		stats, ok := history.Words[word]
+		if !ok {
+			stats = &WordStats{}
+			history.Words[word] = stats
+		}
+		stats.LastSeen = now
+		if protected {
+			stats.Protected++
+			continue
+		}
+		stats.Hits++
+		if len(techs) > 0 && stats.Tech == nil {
+			stats.Tech = make(map[string]int)
+		}
+		for _, tech := range techs {
+			stats.Tech[tech]++
+		}
+	}
+
+	history.Scans++
+	s.historyRecorded = len(counted)
+	s.historyErr = history.Save(s.Config.HistoryFile)
+}
+
+// reorderByHistory applies -reorder to the wordlist. With -reorder-tech, /
+// is fingerprinted first so history from the same stack ranks higher.
+func (s *Scanner) reorderByHistory(words []string) []string {
+	s.historyMoved, s.historyErr = 0, nil
+	if !s.Config.Reorder || s.Config.HistoryFile == "" {
+		return words
+	}
+
+	history, err := LoadHitHistory(s.Config.HistoryFile)
+	if err != nil {
+		s.historyErr = err
+		return words
+	}
+
+	var techs []string
+	if s.Config.ReorderTech {
+		if root, err := s.FetchWithRedirectTracking(s.BaseURL + "/"); err == nil {
+			s.detectTechnologies(root)
+		}
+		for _, tech := range s.SortedTechnologies() {
+			techs = append(techs, tech.Name)
+		}
+	}
+
+	words, s.historyMoved = history.Reorder(words, techs)
+	return words
+}
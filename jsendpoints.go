@@ -0,0 +1,170 @@
+package main
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// JAVASCRIPT ENDPOINT EXTRACTION
+// ===========================================================================
+
+const SourceJS = "js"
+
+var (
+	// fetch("/api/x"), axios.get('/api/x'), $.post("/x"), xhr.open("GET", "/x")
+	jsCallRegex = regexp.MustCompile(`(?:\bfetch|\baxios(?:\.(?:get|post|put|patch|delete|head|request))?|\$\.(?:ajax|get|post|getJSON)|\.open)\s*\(\s*(?:["'][A-Z]+["']\s*,\s*)?["'` + "`" + `]([^"'` + "`" + `\s]+)["'` + "`" + `]`)
+	// url: "/api/x", path: '/users/:id', endpoint: "..." in config objects and route tables
+	jsKeyRegex = regexp.MustCompile(`\b(?:url|uri|path|endpoint|href|action|baseURL|baseUrl|route)\s*:\s*["'` + "`" + `]([^"'` + "`" + `\s]+)["'` + "`" + `]`)
+	// router.get('/x', ...), app.post("/x", ...), Route path="/x"
+	jsRouteRegex = regexp.MustCompile(`\b(?:router|app|route|routes)\.(?:get|post|put|patch|delete|all|use|route)\s*\(\s*["'` + "`" + `]([^"'` + "`" + `\s]+)["'` + "`" + `]`)
+	// Any quoted string that looks like an absolute path or full URL
+	jsPathRegex = regexp.MustCompile(`["'` + "`" + `]((?:https?:)?/[a-zA-Z0-9_\-./~%:{}$@]+)["'` + "`" + `]`)
+	// Quoted relative paths with at least one slash, e.g. "api/v1/users"
+	jsRelativeRegex = regexp.MustCompile(`["'` + "`" + `]((?:[a-zA-Z0-9_\-]+/)+[a-zA-Z0-9_\-.]*)["'` + "`" + `]`)
+
+	// Date formats like "MM/DD/YYYY" match the relative path shape
+	jsDateFormatRegex = regexp.MustCompile(`(?i)^[dmyhs]{1,4}(?:/[dmyhs]{1,4})+$`)
+
+	// Strings that match the path shape but are never endpoints
+	jsNoisePrefixes = []string{
+		"application/", "text/", "image/", "audio/", "video/", "font/", "multipart/",
+		"//", "/*", "./", "../",
+	}
+)
+
+// isJavaScriptResult reports whether a result is a JS file by path or Content-Type
+func isJavaScriptResult(result *ScanResult) bool {
+	ext := strings.ToLower(path.Ext(strings.SplitN(result.OriginalPath, "?", 2)[0]))
+	if ext == ".js" || ext == ".mjs" || ext == ".jsx" {
+		return true
+	}
+	if result.ContentType == "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+	return strings.Contains(mediaType, "javascript") || mediaType == "text/ecmascript"
+}
+
+// ExtractJSEndpoints finds URL paths, fetch/axios/XHR calls and route
+// definitions in a JavaScript body. Route parameters (":id", "{id}",
+// "${id}") cut the path short so the static prefix can still be probed.
+func ExtractJSEndpoints(body []byte) []string {
+	content := string(body)
+	seen := make(map[string]bool)
+	var endpoints []string
+
+	add := func(candidate string) {
+		candidate = cleanJSEndpoint(candidate)
+		if candidate == "" || seen[candidate] {
+			return
+		}
+		seen[candidate] = true
+		endpoints = append(endpoints, candidate)
+	}
+
+	for _, re := range []*regexp.Regexp{jsCallRegex, jsKeyRegex, jsRouteRegex, jsPathRegex, jsRelativeRegex} {
+		for _, m := range re.FindAllStringSubmatch(content, -1) {
+			add(m[1])
+		}
+	}
+
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+func cleanJSEndpoint(candidate string) string {
+	candidate = strings.TrimSpace(candidate)
+	for _, prefix := range jsNoisePrefixes {
+		if strings.HasPrefix(candidate, prefix) {
+			return ""
+		}
+	}
+
+	// Drop query strings and fragments, they don't help path discovery
+	if i := strings.IndexAny(candidate, "?#"); i >= 0 {
+		candidate = candidate[:i]
+	}
+
+	// Cut at the first route parameter or template expression in the path
+	pathStart := 0
+	if i := strings.Index(candidate, "://"); i >= 0 {
+		pathStart = len(candidate)
+		if j := strings.Index(candidate[i+3:], "/"); j >= 0 {
+			pathStart = i + 3 + j
+		}
+	}
+	if i := strings.IndexAny(candidate[pathStart:], "{$:*"); i >= 0 {
+		candidate = candidate[:pathStart+i]
+	}
+	candidate = strings.TrimRight(candidate, "/.")
+
+	if len(candidate) < 2 || !strings.ContainsAny(candidate, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return ""
+	}
+	if jsDateFormatRegex.MatchString(candidate) {
+		return ""
+	}
+	if crawlSkipExts[strings.ToLower(path.Ext(candidate))] {
+		return ""
+	}
+	return candidate
+}
+
+// extractJSResult scans a JavaScript hit for endpoints, records them against
+// the file and queues the in-scope ones for probing.
+func (s *Scanner) extractJSResult(result *ScanResult) {
+	if result.FinalStatus != 200 || len(result.body) == 0 || !isJavaScriptResult(result) {
+		return
+	}
+
+	endpoints := ExtractJSEndpoints(result.body)
+	if len(endpoints) == 0 {
+		return
+	}
+
+	s.Stats.mu.Lock()
+	s.Stats.JSEndpoints[result.OriginalPath] = endpoints
+	s.Stats.mu.Unlock()
+
+	// Absolute paths are relative to the host, bare paths to the scan base
+	hostRoot, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return
+	}
+	hostRoot.Path = "/"
+	base, _ := url.Parse(s.BaseURL + "/")
+
+	var candidates []string
+	for _, endpoint := range endpoints {
+		ref, err := url.Parse(endpoint)
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(endpoint, "/") || ref.IsAbs() {
+			candidates = append(candidates, hostRoot.ResolveReference(ref).String())
+		} else {
+			candidates = append(candidates, base.ResolveReference(ref).String())
+		}
+	}
+	s.queueDiscovered(candidates, SourceJS)
+}
+
+// SortedJSEndpoints returns the JS files with endpoints, ordered by path
+func (s *Scanner) SortedJSEndpoints() ([]string, map[string][]string) {
+	s.Stats.mu.Lock()
+	defer s.Stats.mu.Unlock()
+
+	files := make([]string, 0, len(s.Stats.JSEndpoints))
+	copied := make(map[string][]string, len(s.Stats.JSEndpoints))
+	for file, endpoints := range s.Stats.JSEndpoints {
+		files = append(files, file)
+		copied[file] = endpoints
+	}
+	sort.Strings(files)
+	return files, copied
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractJSEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "fetch, axios, jQuery and XHR calls",
+			body: `fetch("/api/users");axios.post('/api/login',d);$.getJSON("/data/feed");xhr.open("GET", "/legacy/poll")`,
+			want: []string{"/api/login", "/api/users", "/data/feed", "/legacy/poll"},
+		},
+		{
+			name: "config keys and route tables",
+			body: `const c={baseURL:"https://api.example.com/v2",endpoint:'/graphql'};router.get('/admin/stats',h)`,
+			want: []string{"/admin/stats", "/graphql", "https://api.example.com/v2"},
+		},
+		{
+			name: "route parameters cut the path",
+			body: `path: '/users/:id/edit', a="/orders/{orderId}", b=` + "`/items/${id}`",
+			want: []string{"/items", "/orders", "/users"},
+		},
+		{
+			name: "query strings and fragments dropped",
+			body: `fetch("/search?q=1");href: "/docs/#intro"`,
+			want: []string{"/docs", "/search"},
+		},
+		{
+			name: "relative paths",
+			body: `url: "api/v1/reports", y='static/app.bundle.js'`,
+			want: []string{"api/v1/reports", "static/app.bundle.js"},
+		},
+		{
+			name: "noise skipped",
+			body: `t="application/json";c="//cdn.example.com/x";r="./local/file";f="MM/DD/YYYY";i="/img/logo.png";s="/";n="/123"`,
+			want: nil,
+		},
+		{
+			name: "duplicates collapsed",
+			body: `fetch("/api/users");fetch('/api/users');u="/api/users/"`,
+			want: []string{"/api/users"},
+		},
+	}
+	for _, tt := range tests {
+		if got := ExtractJSEndpoints([]byte(tt.body)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsJavaScriptResult(t *testing.T) {
+	tests := []struct {
+		path        string
+		contentType string
+		want        bool
+	}{
+		{"/static/app.js", "", true},
+		{"/static/app.mjs?v=3", "", true},
+		{"/bundle", "application/javascript; charset=utf-8", true},
+		{"/bundle", "text/ecmascript", true},
+		{"/index.html", "text/html", false},
+		{"/data", "", false},
+	}
+	for _, tt := range tests {
+		result := &ScanResult{OriginalPath: tt.path, ContentType: tt.contentType}
+		if got := isJavaScriptResult(result); got != tt.want {
+			t.Errorf("isJavaScriptResult(%q, %q) = %v, want %v", tt.path, tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ===========================================================================
+// RESPONSE-TIME ANOMALIES
+// ===========================================================================
+
+const (
+	// MinLatencySamples is how many responses a status class needs before
+	// anything in it can be flagged as slow
+	MinLatencySamples = 50
+
+	// MaxLatencySamples caps the samples kept per class; older ones are
+	// overwritten so the baseline follows the server's current load
+	MaxLatencySamples = 5000
+
+	// LatencyMADFactor is k in the threshold p99 + k·MAD
+	LatencyMADFactor = 3.0
+
+	// MinLatencyExcess keeps jitter on very fast servers from being flagged:
+	// a slow response must also be this far above the median
+	MinLatencyExcess = 250 * time.Millisecond
+
+	// latencyRefresh is how many new samples trigger a threshold recompute
+	latencyRefresh = 25
+)
+
+// LatencyBaseline is the response-time distribution of one status class
+type LatencyBaseline struct {
+	Class     int // 2 for 2xx, 4 for 4xx...
+	Samples   int
+	Median    time.Duration
+	P99       time.Duration
+	MAD       time.Duration // Median absolute deviation from Median
+	Threshold time.Duration // Responses slower than this are anomalies
+}
+
+type latencyClass struct {
+	samples  []time.Duration
+	next     int // Ring position once samples is full
+	pending  int // Samples added since the baseline was computed
+	baseline LatencyBaseline
+}
+
+// latencyProfile keeps a baseline per status class
+type latencyProfile struct {
+	mu      sync.Mutex
+	classes map[int]*latencyClass
+}
+
+func newLatencyProfile() *latencyProfile {
+	return &latencyProfile{classes: make(map[int]*latencyClass)}
+}
+
+// add records a response time and returns the class baseline, which is only
+// usable once Samples reaches MinLatencySamples
+func (lp *latencyProfile) add(status int, d time.Duration) LatencyBaseline {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	class := status / 100
+	c, ok := lp.classes[class]
+	if !ok {
+		c = &latencyClass{baseline: LatencyBaseline{Class: class}}
+		lp.classes[class] = c
+	}
+
+	if len(c.samples) < MaxLatencySamples {
+		c.samples = append(c.samples, d)
+	} else {
+		c.samples[c.next] = d
+		c.next = (c.next + 1) % MaxLatencySamples
+	}
+	c.pending++
+
+	if c.pending >= latencyRefresh || c.baseline.Samples < MinLatencySamples {
+		c.baseline = computeLatencyBaseline(class, c.samples)
+		c.pending = 0
+	}
+	return c.baseline
+}
+
+// baselines returns every class's baseline, 2xx first
+func (lp *latencyProfile) baselines() []LatencyBaseline {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	var all []LatencyBaseline
+	for _, c := range lp.classes {
+		all = append(all, computeLatencyBaseline(c.baseline.Class, c.samples))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Class < all[j].Class
+	})
+	return all
+}
+
+func computeLatencyBaseline(class int, samples []time.Duration) LatencyBaseline {
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	b := LatencyBaseline{Class: class, Samples: len(sorted)}
+	if len(sorted) == 0 {
+		return b
+	}
+	b.Median = sorted[len(sorted)/2]
+	b.P99 = sorted[(len(sorted)-1)*99/100]
+
+	deviations := make([]time.Duration, len(sorted))
+	for i, d := range sorted {
+		if d > b.Median {
+			deviations[i] = d - b.Median
+		} else {
+			deviations[i] = b.Median - d
+		}
+	}
+	sort.Slice(deviations, func(i, j int) bool { return deviations[i] < deviations[j] })
+	b.MAD = deviations[len(deviations)/2]
+
+	b.Threshold = b.P99 + time.Duration(LatencyMADFactor*float64(b.MAD))
+	if floor := b.Median + MinLatencyExcess; b.Threshold < floor {
+		b.Threshold = floor
+	}
+	return b
+}
+
+// String is the baseline as shown in the report
+func (b LatencyBaseline) String() string {
+	return fmt.Sprintf("%dxx: median %s, p99 %s, MAD %s, slow above %s (%d samples)",
+		b.Class, formatLatency(b.Median), formatLatency(b.P99), formatLatency(b.MAD), formatLatency(b.Threshold), b.Samples)
+}
+
+// checkLatency adds a response to its class baseline and flags it if it's
+// slower than the class threshold. A second request confirms it, so a
+// single network hiccup isn't reported as a backend handler.
+func (s *Scanner) checkLatency(result *ScanResult) {
+	baseline := s.latency.add(result.FinalStatus, result.ResponseTime)
+	if baseline.Samples < MinLatencySamples || result.ResponseTime <= baseline.Threshold {
+		return
+	}
+
+	repeat, err := s.FetchWithRedirectTracking(result.OriginalURL)
+	if err != nil || repeat.FinalStatus != result.FinalStatus || repeat.ResponseTime <= baseline.Threshold {
+		return
+	}
+
+	result.SlowThreshold = baseline.Threshold
+	s.Stats.mu.Lock()
+	s.Stats.SlowResponses = append(s.Stats.SlowResponses, result)
+	s.Stats.mu.Unlock()
+}
+
+// showSlowCandidate keeps a slow response that ScanPath is about to drop
+// (a filtered 404, a catch-all page, a noise cluster) visible in the live
+// results - its timing is the finding, not its content.
+func (s *Scanner) showSlowCandidate(result *ScanResult) {
+	if result.SlowThreshold == 0 {
+		return
+	}
+	result.header = nil
+	result.body = nil
+	result.response = nil
+
+	s.resultsMutex.Lock()
+	s.lastResults = append(s.lastResults, result)
+	if len(s.lastResults) > 100 {
+		s.lastResults = s.lastResults[len(s.lastResults)-100:]
+	}
+	s.resultsMutex.Unlock()
+}
+
+// slowTag is the live view's marker for a latency anomaly
+func slowTag(result *ScanResult) string {
+	if result.SlowThreshold == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[SLOW %s > %s]", formatLatency(result.ResponseTime), formatLatency(result.SlowThreshold))
+}
+
+// slowThreshold is the exceeded threshold for exports ("" if not slow)
+func slowThreshold(result *ScanResult) string {
+	if result.SlowThreshold == 0 {
+		return ""
+	}
+	return formatLatency(result.SlowThreshold)
+}
+
+func formatLatency(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// SortedSlowResponses returns latency anomalies, slowest first
+func (s *Scanner) SortedSlowResponses() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.SlowResponses))
+	copy(sorted, s.Stats.SlowResponses)
+	s.Stats.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ResponseTime > sorted[j].ResponseTime
+	})
+	return sorted
+}
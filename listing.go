@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// DIRECTORY LISTING DETECTION
+// ===========================================================================
+
+const SourceListing = "listing"
+
+// MaxListingEntries caps how many entries of one listing are kept for the report
+const MaxListingEntries = 500
+
+type listingSignature struct {
+	Server  string
+	Pattern *regexp.Regexp
+}
+
+// Checked in order, the first match names the listing style
+var listingSignatures = []listingSignature{
+	{"IIS", regexp.MustCompile(`(?i)\[To Parent Directory\]`)},
+	{"Tomcat", regexp.MustCompile(`<title>Directory Listing For [^<]*</title>`)},
+	{"Python http.server", regexp.MustCompile(`<title>Directory listing for [^<]*</title>`)},
+	{"Jetty", regexp.MustCompile(`(?i)<title>Directory: /[^<]*</title>`)},
+	{"Node serve-index", regexp.MustCompile(`(?i)<title>listing directory /[^<]*</title>`)},
+	{"Caddy", regexp.MustCompile(`(?is)<div class="listing">.*?Modified`)},
+	{"lighttpd", regexp.MustCompile(`(?is)<title>Index of /[^<]*</title>.*?<div class="foot">lighttpd`)},
+	{"nginx", regexp.MustCompile(`(?is)<title>Index of /[^<]*</title>.*?<h1>Index of /[^<]*</h1><hr><pre>`)},
+	{"Apache", regexp.MustCompile(`(?is)<title>Index of /[^<]*</title>.*?(?:Parent Directory|<address>Apache)`)},
+	{"Generic", regexp.MustCompile(`(?i)<title>Index of /[^<]*</title>`)},
+}
+
+// DetectDirectoryListing reports whether an HTML body is an auto-generated
+// directory index, and which server style produced it.
+func DetectDirectoryListing(body []byte) (string, bool) {
+	for _, sig := range listingSignatures {
+		if sig.Pattern.Match(body) {
+			return sig.Server, true
+		}
+	}
+	return "", false
+}
+
+// ParseListingEntries returns the absolute URLs of the files and
+// subdirectories linked from a listing page. Sort links (?C=N;O=D), the
+// parent directory and anything outside the listed directory are skipped.
+func ParseListingEntries(body []byte, pageURL string) []string {
+	// Listing links are relative to the directory, so make sure the page
+	// URL has its trailing slash before resolving them
+	dirURL, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	dirURL.RawQuery = ""
+	dirURL.Fragment = ""
+	if !strings.HasSuffix(dirURL.Path, "/") {
+		dirURL.Path += "/"
+		dirURL.RawPath = ""
+	}
+	dirPath := dirURL.EscapedPath()
+
+	var entries []string
+	for _, link := range ExtractLinks(body, dirURL.String()) {
+		entry, err := url.Parse(link)
+		if err != nil || entry.RawQuery != "" {
+			continue
+		}
+		if !strings.EqualFold(entry.Host, dirURL.Host) {
+			continue
+		}
+		entryPath := entry.EscapedPath()
+		if entryPath == dirPath || !strings.HasPrefix(entryPath, dirPath) {
+			continue
+		}
+		entries = append(entries, link)
+	}
+	return entries
+}
+
+// listingResult flags a directory listing and queues every entry in it
+func (s *Scanner) listingResult(result *ScanResult) {
+	if result.FinalStatus != 200 || len(result.body) == 0 || !isHTMLContent(result.ContentType, result.body) {
+		return
+	}
+
+	server, ok := DetectDirectoryListing(result.body)
+	if !ok {
+		return
+	}
+	entries := ParseListingEntries(result.body, result.FinalURL)
+
+	result.Listing = server
+	for _, entry := range entries {
+		if len(result.ListingEntries) >= MaxListingEntries {
+			break
+		}
+		if u, err := url.Parse(entry); err == nil {
+			result.ListingEntries = append(result.ListingEntries, u.Path)
+		}
+	}
+
+	s.Stats.mu.Lock()
+	s.Stats.Listings = append(s.Stats.Listings, result)
+	s.Stats.mu.Unlock()
+
+	s.queueDiscovered(entries, SourceListing)
+}
+
+// SortedListings returns the directory listings found, ordered by path
+func (s *Scanner) SortedListings() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.Listings))
+	copy(sorted, s.Stats.Listings)
+	s.Stats.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OriginalPath < sorted[j].OriginalPath
+	})
+	return sorted
+}
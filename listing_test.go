@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectDirectoryListing(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		server string
+	}{
+		{"apache", `<html><head><title>Index of /files</title></head><body><h1>Index of /files</h1><a href="/">Parent Directory</a></body></html>`, "Apache"},
+		{"nginx", `<html><head><title>Index of /files/</title></head><body><h1>Index of /files/</h1><hr><pre><a href="../">../</a>`, "nginx"},
+		{"iis", `<pre><A HREF="/">[To Parent Directory]</A><br><br>`, "IIS"},
+		{"tomcat", `<title>Directory Listing For /docs/</title>`, "Tomcat"},
+		{"python", `<title>Directory listing for /</title>`, "Python http.server"},
+		{"generic", `<title>Index of /uploads</title>`, "Generic"},
+		{"ordinary page", `<title>Welcome</title><p>Index of our products</p>`, ""},
+	}
+	for _, tt := range tests {
+		server, ok := DetectDirectoryListing([]byte(tt.body))
+		if server != tt.server || ok != (tt.server != "") {
+			t.Errorf("%s: got (%q, %v), want %q", tt.name, server, ok, tt.server)
+		}
+	}
+}
+
+func TestParseListingEntries(t *testing.T) {
+	body := `<html><head><title>Index of /files</title></head><body><h1>Index of /files</h1><pre>
+<a href="?C=N;O=D">Name</a> <a href="?C=M;O=A">Last modified</a>
+<a href="/">Parent Directory</a>
+<a href="backup.zip">backup.zip</a>
+<a href="old/">old/</a>
+<a href="/files/notes.txt">notes.txt</a>
+<a href="/other/secret.txt">secret.txt</a>
+<a href="https://elsewhere.example/files/x">x</a>
+</pre></body></html>`
+
+	want := []string{
+		"https://example.com/files/backup.zip",
+		"https://example.com/files/old/",
+		"https://example.com/files/notes.txt",
+	}
+	// The page URL's missing trailing slash must not change the result
+	for _, pageURL := range []string{"https://example.com/files", "https://example.com/files/"} {
+		if got := ParseListingEntries([]byte(body), pageURL); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseListingEntries(%q) = %q, want %q", pageURL, got, want)
+		}
+	}
+}
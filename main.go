@@ -226,6 +226,33 @@ type ScanResult struct {
 	IsDirect200     bool
 	ResponseTime    time.Duration
 	Timestamp       time.Time
+	Title           string
+	Server          string
+	ContentType     string
+	PoweredBy       string
+	Headers         map[string]string // Extra headers kept via -keep-headers
+	Source          string            // How the path was discovered (wordlist, crawl)
+	Findings        []SensitiveFinding
+	VariantOf       string        // Hit this backup/artifact variant was derived from
+	Listing         string        // Directory listing style (Apache, nginx, IIS...) if this is one
+	ListingEntries  []string      // Paths linked from the listing
+	Kind            PathKind      // File or directory, from response evidence
+	KindEvidence    string        // What decided Kind
+	StoredAs        string        // Request/response record in the -store directory
+	BodyFile        string        // Content-addressed body in the -store directory
+	SimHash         uint64        // Near-duplicate fingerprint of the body
+	ClusterID       int           // Similarity cluster this result belongs to
+	OpenRedirect    *OpenRedirect // Confirmed open redirect, with the payload
+	Bypasses        []Bypass      // Variants that changed a 401/403's status
+	SlowThreshold   time.Duration // Set when ResponseTime was a latency anomaly
+	Words           int           // Whitespace-separated words in the body
+	Lines           int           // Lines in the body
+
+	// Raw response, only held until the analyzers in ScanPath have run
+	header   http.Header
+	body     []byte
+	response *http.Response // Final response (body already read), for the store
+	cluster  *Cluster
 }
 
 type LiveStats struct {
@@ -252,6 +279,25 @@ type Statistics struct {
 	RedirectTargets map[string]int
 	ContentHashes   map[string][]*ScanResult
 	OtherCodes      []*ScanResult
+	Technologies    map[string]*DetectedTech
+	JSEndpoints     map[string][]string // JS file path -> endpoints found in it
+	Seeds           SeedSummary
+	Sensitive       []*ScanResult // Results with sensitive content findings
+	Backups         []*ScanResult // Backup/artifact variants that were found
+	Listings        []*ScanResult // Pages with open directory indexing
+	Clusters        []*Cluster    // Exact and near-duplicate response groups
+	OpenRedirects   []*ScanResult // Redirects confirmed to go off-site
+	Bypasses        []*ScanResult // 401/403 results with a variant that changed the status
+	SlowResponses   []*ScanResult // Latency anomalies, including dropped 404s
+}
+
+func NewStatistics() *Statistics {
+	return &Statistics{
+		RedirectTargets: make(map[string]int),
+		ContentHashes:   make(map[string][]*ScanResult),
+		Technologies:    make(map[string]*DetectedTech),
+		JSEndpoints:     make(map[string][]string),
+	}
 }
 
 type WildcardBaseline struct {
@@ -261,21 +307,51 @@ type WildcardBaseline struct {
 }
 
 type Config struct {
-	StatusCodes    []int
-	FilterStatuses []int
-	FilterSizes    []int
-	MatchRegex     *regexp.Regexp
-	Extensions     []string
-	CustomHeaders  map[string]string
-	Cookie         string
-	Method         string
-	RateLimit      int
-	Delay          time.Duration
-	Recursive      bool
-	RecursionDepth int
-	OutputFile     string
-	OutputFormat   string
-	Theme          string
+	StatusCodes       []int
+	FilterStatuses    []int
+	FilterSizes       []int
+	MatchHeaders      []HeaderRule
+	FilterHeaders     []HeaderRule
+	KeepHeaders       []string
+	MatchRegex        *regexp.Regexp
+	Wordlist          string         // -wordlist file ("" = built-in list)
+	Generator         *PathGenerator // -wordlist generator, streamed instead of loaded
+	Categories        []string       // Built-in list sections to scan (empty = all)
+	Extensions        []string
+	Rules             []MutationRule // -rules wordlist mutations
+	CustomHeaders     map[string]string
+	Cookie            string
+	Method            string
+	RateLimit         int
+	Delay             time.Duration
+	Recursive         bool
+	RecursionDepth    int
+	RecursionOrder    string
+	RecursionInclude  []string // Only recurse into directories matching these
+	RecursionExclude  []string // Never scan or recurse under these
+	RecursionBudget   int      // Max children queued per directory (0 = unlimited)
+	RecursionStatuses []int    // Statuses that trigger recursion (empty = any)
+	Crawl             bool
+	ExtractJS         bool
+	Seed              bool
+	Backups           bool
+	StoreDir          string
+	RedirectCheck     bool
+	Bypass            bool
+	BypassUnsafe      bool // Also try POST and PATCH as bypass methods
+	Profile           bool
+	AutoCalibrate     bool
+	AutoExtensions    bool   // Pick extensions from the fingerprinted stack
+	Harvest           bool   // Collect words from responses
+	HarvestPass       bool   // Scan the top harvested words after the wordlist
+	HarvestTop        int    // Words used by the second pass
+	HarvestOut        string // Save ranked words here ({host} = target host)
+	HistoryFile       string // Hit-history store ("" = don't record)
+	Reorder           bool   // Try words with past hits first
+	ReorderTech       bool   // Weigh past hits on the same stack higher
+	OutputFile        string
+	OutputFormat      string
+	Theme             string
 }
 
 type Scanner struct {
@@ -289,14 +365,36 @@ type Scanner struct {
 	WildcardBaseline *WildcardBaseline
 	Config           *Config
 	visitedPaths     map[string]bool
+	pathSources      map[string]string            // visitKey -> discovery source for queued paths
+	variantOf        map[string]*ScanResult       // visitKey -> hit a backup variant came from
+	dirBaselines     map[string]*WildcardBaseline // visitKey -> catch-all baseline for a directory
+	redirectTried    map[string]bool              // Open redirect payload URLs already sent
+	bypassBaselines  map[string]string            // Rewrite-header request -> ContentHash without the header
+	profile          ServerProfile // Calibrated path normalization, guarded by pathMutex
+	profiling        bool
+	latency          *latencyProfile
+	calibration      *AutoCalibration // -ac filter rules, derived when the scan starts
 	pathMutex        sync.Mutex
-	recursionQueue   chan string
-	recursionWg      sync.WaitGroup
+	store            *ResponseStore
+	queue            *scanQueue
+	crawlQueued      int64
+	recursionWords   []string // Wordlist appended to each discovered directory
+	wordlistWords    int      // Wordlist size before -rules
+	wordlistExpanded int      // Wordlist size after -rules
+	harvest          *wordHarvester
+	extensions       *extensionInference // -auto-x picks, nil when off
+	historyMoved     int                 // Words moved up by -reorder
+	historyRecorded  int                 // Words added to the history this scan
+	historyErr       error
+	harvestQueued    int   // Paths queued by the harvest second pass
+	harvestFile      string
+	harvestErr       error
 	rateLimiter      <-chan time.Time
 	lastResults      []*ScanResult
 	resultsMutex     sync.Mutex
 	cancelScan       bool         // Flag to cancel active scan
 	cancelMutex      sync.Mutex   // Mutex for cancel flag
+	recursionMutex   sync.RWMutex // Guards the Config recursion settings the F4 menu changes mid-scan
 }
 
 // ===========================================================================
@@ -555,17 +653,22 @@ type TUI struct {
 	skittlesGlobePos    [16][2]int      // Random positions for colored globe chars
 	lastTheme           string
 	showSplash          bool
-	splashProgress      float64 // 0.0 to 1.0 for animation
-	progressBarFrame    int     // Animation frame for progress bar
-	inputText           string  // User input text for URLs/domains
-	inputActive         bool    // Whether input field is active
-	showConfigMenu      bool    // Whether config menu is visible
-	configMenuSelected  int     // Currently selected menu item
-	configEditMode      bool    // Whether editing a config value
-	configEditText      string  // Temporary text while editing
-	showHelpScreen      bool    // Whether help screen is visible
-	resultsScrollOffset int     // Scroll offset for live results
-	helpScrollOffset    int     // Scroll offset for help screen
+	splashProgress      float64     // 0.0 to 1.0 for animation
+	progressBarFrame    int         // Animation frame for progress bar
+	inputText           string      // User input text for URLs/domains
+	inputActive         bool        // Whether input field is active
+	showConfigMenu      bool        // Whether config menu is visible
+	configMenuSelected  int         // Currently selected menu item
+	configEditMode      bool        // Whether editing a config value
+	configEditText      string      // Temporary text while editing
+	showHelpScreen      bool        // Whether help screen is visible
+	resultsScrollOffset int         // Scroll offset for live results
+	helpScrollOffset    int         // Scroll offset for help screen
+	selectedResult      *ScanResult // Live result picked with Tab, opened with O
+	showResponseViewer  bool        // Whether the stored response viewer is visible
+	viewerTitle         string      // Result shown in the viewer
+	viewerLines         []string    // Stored request/response lines
+	viewerScroll        int         // Scroll offset for the viewer
 
 	// Pathfinding maze animation
 	mazeWidth              int
@@ -1046,6 +1149,9 @@ func (tui *TUI) Render() {
 	} else if tui.showHelpScreen {
 		tui.renderDashboard()
 		tui.renderHelpScreen()
+	} else if tui.showResponseViewer {
+		tui.renderDashboard()
+		tui.renderResponseViewer()
 	} else {
 		tui.renderDashboard()
 		if tui.showConfigMenu {
@@ -1448,8 +1554,14 @@ func (tui *TUI) renderDashboard() {
 	methodText := fmt.Sprintf("Method: %s  Timeout: %ds", tui.scanner.Config.Method, int(tui.scanner.Timeout.Seconds()))
 	tui.drawText(4, 9, methodText, tcell.StyleDefault.Foreground(CurrentTheme.Info))
 
-	timeoutHelp := "(max wait per request)"
-	tui.drawText(4, 10, timeoutHelp, tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true).Italic(true))
+	// Extensions in use replace the timeout hint when there are any
+	if len(tui.scanner.Config.Extensions) > 0 || tui.scanner.Config.AutoExtensions {
+		extText := truncateString(tui.scanner.extensionsSummary(), titleWidth/2-5)
+		tui.drawText(4, 10, extText, tcell.StyleDefault.Foreground(CurrentTheme.Warning))
+	} else {
+		timeoutHelp := "(max wait per request)"
+		tui.drawText(4, 10, timeoutHelp, tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true).Italic(true))
+	}
 
 	concText := fmt.Sprintf("Concurrency: %d", tui.scanner.Concurrency)
 	tui.drawText(4, 11, concText, textStyle)
@@ -1462,11 +1574,11 @@ func (tui *TUI) renderDashboard() {
 
 	// Show wordlist size (number of paths loaded)
 	wordlistCount := tui.scanner.LiveStats.TotalRequests
-	wordlistText := "Wordlist: wordlist.txt"
-	if wordlistCount > 0 {
-		wordlistText = fmt.Sprintf("Wordlist: wordlist.txt (%d paths)", wordlistCount)
+	wordlistText := tui.scanner.wordlistSummary(wordlistName(tui.scanner.Config))
+	if wordlistCount > 0 && len(tui.scanner.Config.Rules) == 0 {
+		wordlistText = fmt.Sprintf("Wordlist: %s (%d paths)", wordlistName(tui.scanner.Config), wordlistCount)
 	}
-	tui.drawText(4, 13, wordlistText, tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true))
+	tui.drawText(4, 13, truncateString(wordlistText, titleWidth/2-5), tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true))
 
 	// Progress box with animated bar (increased height to fit duration)
 	tui.drawBox(titleWidth/2+2, 3, titleWidth/2+1, 6, "PROGRESS", progressBoxStyle)
@@ -1539,7 +1651,11 @@ func (tui *TUI) renderDashboard() {
 	tui.drawText(titleWidth/2+4, 6, durationProgressText, tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true))
 
 	// Draw target acquired indicator if scan is running (moved below progress box)
-	if completed > 0 && completed < total {
+	// With recursion, that line shows per-depth progress instead
+	if progress := tui.scanner.DepthProgress(); tui.scanner.Config.Recursive && len(progress) > 1 {
+		depthText := truncateString(depthProgressText(progress), titleWidth/2-4)
+		tui.drawText(titleWidth/2+4, 7, depthText, tcell.StyleDefault.Foreground(CurrentTheme.Primary))
+	} else if completed > 0 && completed < total {
 		targetMsg := "⚡ Target acquired →"
 		tui.drawText(titleWidth/2+4, 7, targetMsg, tcell.StyleDefault.Foreground(CurrentTheme.Primary))
 	}
@@ -1572,6 +1688,16 @@ func (tui *TUI) renderDashboard() {
 	speedText := fmt.Sprintf("%-20s %6.0f req/s", "Speed:", tui.scanner.LiveStats.CurrentSpeed)
 	tui.drawText(4, 20, speedText, tcell.StyleDefault.Foreground(CurrentTheme.Info))
 
+	tui.scanner.Stats.mu.Lock()
+	sensitiveCount := len(tui.scanner.Stats.Sensitive)
+	tui.scanner.Stats.mu.Unlock()
+	sensitiveStyle := tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true)
+	if sensitiveCount > 0 {
+		sensitiveStyle = tcell.StyleDefault.Foreground(CurrentTheme.Danger).Bold(true)
+	}
+	sensitiveText := fmt.Sprintf("%-20s %6d", "Sensitive Content:", sensitiveCount)
+	tui.drawText(4, 21, sensitiveText, sensitiveStyle)
+
 	// Local Network Info box (can be hidden for OpSec/screenshots)
 	mazeYPosition := 31 // Default position below network info (restored to original)
 	if !tui.hideNetworkInfo {
@@ -1612,11 +1738,16 @@ func (tui *TUI) renderDashboard() {
 	// Pathfinding maze animation - position depends on network info visibility
 	tui.renderMaze(2, mazeYPosition)
 
-	// Live results box - extend down to just above controls
+	// Live results box - extend down to just above the technologies panel
 	controlsY := tui.height - 3
-	resultsHeight := controlsY - 9  // From Y=9 to controls box (adjusted for taller Progress box)
+	techBoxHeight := 6
+	techBoxY := controlsY - techBoxHeight
+	resultsHeight := techBoxY - 9  // From Y=9 to technologies panel (adjusted for taller Progress box)
 	tui.drawBox(titleWidth/2+2, 9, titleWidth/2+1, resultsHeight, "LIVE RESULTS", resultsBoxStyle)
 
+	// Detected technologies panel below live results
+	tui.renderTechPanel(titleWidth/2+2, techBoxY, titleWidth/2+1, techBoxHeight, statsBoxStyle)
+
 	tui.scanner.resultsMutex.Lock()
 	results := tui.scanner.lastResults
 	tui.scanner.resultsMutex.Unlock()
@@ -1639,6 +1770,21 @@ func (tui *TUI) renderDashboard() {
 		startIdx = totalResults - maxVisible
 	}
 
+	// Keep the selected result in view
+	for i, result := range results {
+		if result != tui.selectedResult {
+			continue
+		}
+		if i < startIdx {
+			startIdx = i
+			tui.resultsScrollOffset = i
+		} else if i >= startIdx+maxVisible {
+			startIdx = i - maxVisible + 1
+			tui.resultsScrollOffset = startIdx
+		}
+		break
+	}
+
 	for i := startIdx; i < totalResults && i-startIdx < maxVisible; i++ {
 		result := results[i]
 		var label string
@@ -1663,6 +1809,9 @@ func (tui *TUI) renderDashboard() {
 		} else if result.FinalStatus >= 500 {
 			label = "ERROR:"
 			color = CurrentTheme.Danger
+		} else if result.SlowThreshold > 0 {
+			label = "SLOW:"
+			color = CurrentTheme.Warning
 		} else {
 			label = "FAIL:"
 			color = CurrentTheme.Danger
@@ -1696,7 +1845,24 @@ func (tui *TUI) renderDashboard() {
 			line = fmt.Sprintf("%-10s [%d] %s", label, result.FinalStatus, path)
 		}
 
-		tui.drawText(titleWidth/2+4, 10+i-startIdx, line, tcell.StyleDefault.Foreground(color))
+		// Sensitive content overrides the status color so leaks stand out
+		style := tcell.StyleDefault.Foreground(color)
+		if len(result.Findings) > 0 {
+			style = tcell.StyleDefault.Foreground(CurrentTheme.Danger).Bold(true)
+		}
+		if result == tui.selectedResult {
+			style = style.Reverse(true)
+		}
+
+		// Append what the page is (title, server) if there's room left
+		if details := resultDetails(result); details != "" {
+			lineWidth := titleWidth/2 - 4
+			if room := lineWidth - len(line) - 1; room > 8 {
+				line += " " + truncateString(details, room)
+			}
+		}
+
+		tui.drawText(titleWidth/2+4, 10+i-startIdx, line, style)
 	}
 
 	// Show scroll indicator if there are more results
@@ -1739,6 +1905,60 @@ func (tui *TUI) renderDashboard() {
 	tui.drawText(2+controlsX, controlsY+1, controls, tcell.StyleDefault.Foreground(CurrentTheme.Info))
 }
 
+// renderTechPanel lists detected technologies, wrapped to fit the box
+func (tui *TUI) renderTechPanel(x, y, width, height int, style tcell.Style) {
+	techs := tui.scanner.SortedTechnologies()
+	title := "TECHNOLOGIES"
+	if len(techs) > 0 {
+		title = fmt.Sprintf("TECHNOLOGIES (%d)", len(techs))
+	}
+	tui.drawBox(x, y, width, height, title, style)
+
+	innerWidth := width - 4
+	maxLines := height - 2
+	if innerWidth <= 0 || maxLines <= 0 {
+		return
+	}
+
+	if len(techs) == 0 {
+		tui.drawText(x+2, y+1, "No technologies detected yet", tcell.StyleDefault.Foreground(CurrentTheme.Text).Dim(true).Italic(true))
+		return
+	}
+
+	var lines []string
+	current := ""
+	shown := 0
+	for _, tech := range techs {
+		label := tech.Label()
+		candidate := label
+		if current != "" {
+			candidate = current + ", " + label
+		}
+		if len(candidate) <= innerWidth {
+			current = candidate
+			shown++
+			continue
+		}
+		if len(lines) == maxLines-1 {
+			break
+		}
+		lines = append(lines, current+",")
+		current = truncateString(label, innerWidth)
+		shown++
+	}
+	if current != "" {
+		if remaining := len(techs) - shown; remaining > 0 {
+			more := fmt.Sprintf(" +%d more", remaining)
+			current = truncateString(current, innerWidth-len(more)) + more
+		}
+		lines = append(lines, current)
+	}
+
+	for i, line := range lines {
+		tui.drawText(x+2, y+1+i, line, tcell.StyleDefault.Foreground(CurrentTheme.Info))
+	}
+}
+
 func (tui *TUI) exportExecutiveSummary() {
 	// Generate professional pentest report executive summary
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
@@ -1774,6 +1994,11 @@ func (tui *TUI) exportExecutiveSummary() {
 		report.WriteString("Rate Limit:          Unlimited\n")
 	}
 
+	if seeds := tui.scanner.Stats.Seeds; seeds.Total() > 0 {
+		report.WriteString(fmt.Sprintf("Seeded Paths:        %d (robots.txt: %d, sitemaps: %d, .well-known: %d)\n",
+			seeds.Total(), seeds.Robots, seeds.Sitemap, seeds.WellKnown))
+	}
+
 	report.WriteString("\n")
 
 	// Executive Summary Statistics
@@ -1793,6 +2018,115 @@ func (tui *TUI) exportExecutiveSummary() {
 	report.WriteString(fmt.Sprintf("Total Requests:      %d\n", completed))
 	report.WriteString(fmt.Sprintf("Average Speed:       %.0f req/s\n\n", avgSpeed))
 
+	if tui.scanner.Config.AutoExtensions {
+		report.WriteString("EXTENSIONS (-auto-x):\n")
+		picked := tui.scanner.PickedExtensions()
+		if len(picked) == 0 {
+			report.WriteString("  No server-side stack identified - no extensions added\n")
+		}
+		for _, p := range picked {
+			report.WriteString(fmt.Sprintf("  %-20s %s\n", "."+p.Ext, p.Evidence))
+		}
+		report.WriteString("\n")
+	}
+
+	if cfg := tui.scanner.Config; cfg.HistoryFile != "" {
+		report.WriteString("HIT HISTORY:\n")
+		report.WriteString(fmt.Sprintf("  Store:               %s\n", cfg.HistoryFile))
+		if cfg.Reorder {
+			report.WriteString(fmt.Sprintf("  Reordered:           %d words with past hits tried first\n", tui.scanner.historyMoved))
+		}
+		if tui.scanner.historyErr != nil {
+			report.WriteString(fmt.Sprintf("  Error:               %v\n", tui.scanner.historyErr))
+		} else if tui.scanner.LiveStats.EndTime.IsZero() {
+			report.WriteString("  Recorded:            when the scan finishes\n")
+		} else {
+			report.WriteString(fmt.Sprintf("  Recorded:            %d words with findings\n", tui.scanner.historyRecorded))
+		}
+		report.WriteString("\n")
+	}
+
+	if rules := tui.scanner.Config.Rules; len(rules) > 0 {
+		report.WriteString("WORDLIST MUTATIONS:\n")
+		report.WriteString(fmt.Sprintf("  %d words expanded to %d paths by %d rules\n", tui.scanner.wordlistWords, tui.scanner.wordlistExpanded, len(rules)))
+		for _, rule := range rules {
+			report.WriteString(fmt.Sprintf("    %s\n", rule.Text))
+		}
+		report.WriteString("\n")
+	}
+
+	if tui.scanner.Config.Profile {
+		profile := tui.scanner.ServerProfile()
+		report.WriteString("SERVER PROFILE:\n")
+		report.WriteString(fmt.Sprintf("  %s\n", profile.Summary()))
+		if len(profile.Evidence) > 0 {
+			report.WriteString(fmt.Sprintf("  Calibrated on:       %s\n", strings.Join(profile.Evidence, ", ")))
+		}
+		report.WriteString(fmt.Sprintf("  Duplicates skipped:  %d paths\n\n", profile.Deduped))
+	}
+
+	if tui.scanner.calibration != nil {
+		report.WriteString("AUTO-CALIBRATION FILTERS:\n")
+		rules := tui.scanner.calibration.Snapshot()
+		if len(rules) == 0 {
+			report.WriteString("  No stable nonsense response found - nothing filtered\n")
+		}
+		for _, rule := range rules {
+			state := "on"
+			if !rule.Enabled || !tui.scanner.Config.AutoCalibrate {
+				state = "off"
+			}
+			report.WriteString(fmt.Sprintf("  [%-3s] %s (%s) - %d filtered\n", state, rule, rule.Class, rule.Hits()))
+		}
+		report.WriteString("\n")
+	}
+
+	if progress := tui.scanner.DepthProgress(); tui.scanner.Config.Recursive && len(progress) > 0 {
+		report.WriteString(fmt.Sprintf("RECURSION (%s):\n", strings.ToUpper(tui.scanner.Config.RecursionOrder)))
+		for _, p := range progress {
+			line := fmt.Sprintf("  Depth %d:             %d/%d paths, %d directories expanded", p.Depth, p.Done, p.Queued, p.Dirs)
+			if p.Over > 0 {
+				line += fmt.Sprintf(", %d over budget", p.Over)
+			}
+			report.WriteString(line + "\n")
+		}
+		cfg := tui.scanner.Config
+		report.WriteString(fmt.Sprintf("  Recurse on:          %s\n", formatStatusSet(cfg.RecursionStatuses)))
+		if cfg.RecursionBudget > 0 {
+			report.WriteString(fmt.Sprintf("  Dir budget:          %d requests\n", cfg.RecursionBudget))
+		}
+		if len(cfg.RecursionInclude) > 0 {
+			report.WriteString(fmt.Sprintf("  Include:             %s\n", strings.Join(cfg.RecursionInclude, ", ")))
+		}
+		if len(cfg.RecursionExclude) > 0 {
+			report.WriteString(fmt.Sprintf("  Exclude:             %s\n", strings.Join(cfg.RecursionExclude, ", ")))
+		}
+		report.WriteString("\n")
+	}
+
+	if words := tui.scanner.HarvestedWords(); tui.scanner.harvest != nil {
+		report.WriteString("HARVESTED WORDS:\n")
+		report.WriteString(fmt.Sprintf("  Candidates:          %d words from titles, headings, ids and JS names\n", len(words)))
+		if tui.scanner.Config.HarvestPass {
+			report.WriteString(fmt.Sprintf("  Second pass:         %d paths queued\n", tui.scanner.harvestQueued))
+		}
+		if tui.scanner.harvestFile != "" {
+			if tui.scanner.harvestErr != nil {
+				report.WriteString(fmt.Sprintf("  Wordlist:            not saved (%v)\n", tui.scanner.harvestErr))
+			} else {
+				report.WriteString(fmt.Sprintf("  Wordlist:            %s\n", tui.scanner.harvestFile))
+			}
+		}
+		for i, w := range words {
+			if i == 20 {
+				report.WriteString(fmt.Sprintf("  ... and %d more\n", len(words)-20))
+				break
+			}
+			report.WriteString(fmt.Sprintf("  %-24s score %-4d %d page(s), %s\n", w.Word, w.Score, w.Pages, strings.Join(w.Sources, ", ")))
+		}
+		report.WriteString("\n")
+	}
+
 	report.WriteString("FINDINGS BREAKDOWN:\n")
 	report.WriteString(fmt.Sprintf("  [★] TOTAL HITS:      %d paths (All 200 OK responses)\n", totalHits))
 	report.WriteString(fmt.Sprintf("      - Direct 200s:   %d paths (No redirects)\n", direct200s))
@@ -1812,8 +2146,178 @@ func (tui *TUI) exportExecutiveSummary() {
 	if redirects > 0 {
 		report.WriteString(fmt.Sprintf("  • %d redirect chains detected - Analyze for open redirects or misconfigurations\n", redirects))
 	}
+	sensitive := tui.scanner.SortedSensitive()
+	if len(sensitive) > 0 {
+		report.WriteString(fmt.Sprintf("  • %d responses contain sensitive content (secrets, keys, error details) - See below\n", len(sensitive)))
+	}
+	slowResponses := tui.scanner.SortedSlowResponses()
+	if len(slowResponses) > 0 {
+		report.WriteString(fmt.Sprintf("  • %d responses were far slower than their status class - Possible hidden backend handlers\n", len(slowResponses)))
+	}
+	bypasses := tui.scanner.SortedBypasses()
+	bypassed := 0
+	for _, result := range bypasses {
+		if len(result.ConfirmedBypasses()) > 0 {
+			bypassed++
+		}
+	}
+	if bypassed > 0 {
+		report.WriteString(fmt.Sprintf("  • %d protected paths could be reached with a bypass - Access control is not enforced\n", bypassed))
+	}
+	if changed := len(bypasses) - bypassed; changed > 0 {
+		report.WriteString(fmt.Sprintf("  • %d more protected paths answered a bypass variant with another status - Review by hand\n", changed))
+	}
+	openRedirects := tui.scanner.SortedOpenRedirects()
+	if len(openRedirects) > 0 {
+		report.WriteString(fmt.Sprintf("  • %d confirmed open redirects - Usable for phishing and OAuth token theft\n", len(openRedirects)))
+	}
+	listings := tui.scanner.SortedListings()
+	if len(listings) > 0 {
+		report.WriteString(fmt.Sprintf("  • %d directories have open indexing - Every file in them is browsable\n", len(listings)))
+	}
+	backups := tui.scanner.SortedBackups()
+	if len(backups) > 0 {
+		report.WriteString(fmt.Sprintf("  • %d backup or editor-artifact files exposed - May leak source code or credentials\n", len(backups)))
+	}
 	report.WriteString("\n")
 
+	// Sensitive content findings, most severe first
+	if len(sensitive) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ SENSITIVE CONTENT FINDINGS (CRITICAL PRIORITY)                              │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("The following responses matched rules for secrets, credentials, or verbose\n")
+		report.WriteString("error output. Matches are redacted; fetch the URL to review the full content.\n\n")
+
+		for i, result := range sensitive {
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.FinalURL))
+			report.WriteString(fmt.Sprintf("    Status:      %d\n", result.FinalStatus))
+			if result.StoredAs != "" {
+				report.WriteString(fmt.Sprintf("    Stored:      %s\n", result.StoredAs))
+			}
+			for _, finding := range result.Findings {
+				report.WriteString(fmt.Sprintf("    [%s] %s: %s\n", finding.Severity, finding.Rule, finding.Match))
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	// 401/403 bypasses, each with a request that reproduces it
+	if len(bypasses) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ AUTHORIZATION BYPASSES (CRITICAL PRIORITY)                                  │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("These paths answered 401/403, but the variants below got another status.\n")
+		report.WriteString("A 2xx (marked BYPASS) is a confirmed bypass; the rest need a look by hand.\n")
+		report.WriteString("Each is followed by the raw request and a curl command to reproduce it.\n\n")
+
+		for i, result := range bypasses {
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.OriginalURL))
+			report.WriteString(fmt.Sprintf("    Protected:   %d\n", result.FinalStatus))
+			for _, b := range result.Bypasses {
+				mark := "changed"
+				if b.Confirmed {
+					mark = "BYPASS"
+				}
+				report.WriteString(fmt.Sprintf("    - [%s] %s -> %d (%d bytes)", mark, b.Technique, b.Status, b.Length))
+				if b.Location != "" {
+					report.WriteString(" Location: " + b.Location)
+				}
+				report.WriteString("\n")
+				for _, line := range strings.Split(strings.TrimSuffix(b.ReproductionRequest(), "\n"), "\n") {
+					report.WriteString("        " + line + "\n")
+				}
+				report.WriteString("        " + b.CurlCommand() + "\n")
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	// Confirmed open redirects, with the exact request that proves each
+	if len(openRedirects) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ CONFIRMED OPEN REDIRECTS (HIGH PRIORITY)                                    │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString(fmt.Sprintf("Each payload below made the server redirect to the canary host %s.\n", RedirectCanaryHost))
+		report.WriteString("Replace it with any external site to reproduce.\n\n")
+
+		for i, result := range openRedirects {
+			finding := result.OpenRedirect
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    Parameter:   %s\n", finding.Parameter))
+			report.WriteString(fmt.Sprintf("    Payload:     %s\n", finding.PayloadURL))
+			report.WriteString(fmt.Sprintf("    Response:    %d Location: %s\n", finding.Status, finding.Location))
+			report.WriteString("\n")
+		}
+	}
+
+	// Directory listings, with the entries parsed out of each
+	if len(listings) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ DIRECTORY LISTINGS (HIGH PRIORITY)                                          │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("These paths return auto-generated directory indexes. Their entries were\n")
+		report.WriteString("queued for scanning; review them for files that should not be public.\n\n")
+
+		for i, result := range listings {
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.FinalURL))
+			report.WriteString(fmt.Sprintf("    Style:       %s\n", result.Listing))
+			report.WriteString(fmt.Sprintf("    Entries:     %d\n", len(result.ListingEntries)))
+			for _, entry := range result.ListingEntries {
+				report.WriteString(fmt.Sprintf("    - %s\n", entry))
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	// Backup and artifact variants, each linked to the hit it came from
+	if len(backups) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ BACKUP & ARTIFACT FILES (HIGH PRIORITY)                                     │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("Backup copies, editor leftovers and archives found next to confirmed hits.\n")
+		report.WriteString("These often contain unprocessed source code or configuration.\n\n")
+
+		for i, result := range backups {
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    Variant Of:  %s\n", result.VariantOf))
+			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.FinalURL))
+			report.WriteString(fmt.Sprintf("    Size:        %d bytes\n", result.ContentLength))
+			if result.ContentType != "" {
+				report.WriteString(fmt.Sprintf("    Type:        %s\n", result.ContentType))
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	// Detected Technologies
+	if techs := tui.scanner.SortedTechnologies(); len(techs) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ DETECTED TECHNOLOGIES                                                       │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("Technologies fingerprinted from response headers, cookies, page content and\n")
+		report.WriteString("known paths. Versions are shown where the target disclosed them.\n\n")
+
+		for i, tech := range techs {
+			version := tech.Version
+			if version == "" {
+				version = "unknown"
+			}
+			report.WriteString(fmt.Sprintf("[%d] %s\n", i+1, tech.Name))
+			report.WriteString(fmt.Sprintf("    Version:     %s\n", version))
+			if len(tech.Categories) > 0 {
+				report.WriteString(fmt.Sprintf("    Category:    %s\n", strings.Join(tech.Categories, ", ")))
+			}
+			report.WriteString(fmt.Sprintf("    Evidence:    %s\n", tech.Evidence))
+			report.WriteString(fmt.Sprintf("    Found On:    %s\n", tech.FoundOn))
+			report.WriteString(fmt.Sprintf("    Responses:   %d\n", tech.Hits))
+			report.WriteString("\n")
+		}
+	}
+
 	// Detailed Findings - Direct 200s
 	if len(tui.scanner.Stats.Direct200s) > 0 {
 		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
@@ -1828,14 +2332,29 @@ func (tui *TUI) exportExecutiveSummary() {
 		sort.Slice(sortedDirect, func(i, j int) bool {
 			return sortedDirect[i].Timestamp.Before(sortedDirect[j].Timestamp)
 		})
+		sortedDirect = collapseClusters(sortedDirect)
 
 		for i, result := range sortedDirect {
 			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
 			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.OriginalURL))
 			report.WriteString(fmt.Sprintf("    Status:      %d (OK)\n", result.FinalStatus))
+			if result.Title != "" {
+				report.WriteString(fmt.Sprintf("    Title:       %s\n", result.Title))
+			}
+			if ct := shortContentType(result.ContentType); ct != "" {
+				report.WriteString(fmt.Sprintf("    Type:        %s\n", ct))
+			}
+			if result.Kind != KindUnknown {
+				report.WriteString(fmt.Sprintf("    Kind:        %s (%s)\n", result.Kind, result.KindEvidence))
+			}
 			report.WriteString(fmt.Sprintf("    Size:        %s\n", formatSize(result.ContentLength)))
 			report.WriteString(fmt.Sprintf("    Hash:        %s\n", result.ContentHash[:16]))
 			report.WriteString(fmt.Sprintf("    Response:    %dms\n", result.ResponseTime.Milliseconds()))
+			report.WriteString(fmt.Sprintf("    Source:      %s\n", result.Source))
+			if result.StoredAs != "" {
+				report.WriteString(fmt.Sprintf("    Stored:      %s\n", result.StoredAs))
+			}
+			report.WriteString(clusterReportLine(result))
 			report.WriteString(fmt.Sprintf("    Discovered:  %s\n", result.Timestamp.Format("2006-01-02 15:04:05")))
 			report.WriteString("\n")
 		}
@@ -1854,6 +2373,7 @@ func (tui *TUI) exportExecutiveSummary() {
 		sort.Slice(sortedRedirect200s, func(i, j int) bool {
 			return sortedRedirect200s[i].Timestamp.Before(sortedRedirect200s[j].Timestamp)
 		})
+		sortedRedirect200s = collapseClusters(sortedRedirect200s)
 
 		for i, result := range sortedRedirect200s {
 			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
@@ -1862,11 +2382,34 @@ func (tui *TUI) exportExecutiveSummary() {
 			report.WriteString(fmt.Sprintf("    Status:      %d (OK)\n", result.FinalStatus))
 			report.WriteString(fmt.Sprintf("    Size:        %s\n", formatSize(result.ContentLength)))
 			report.WriteString(fmt.Sprintf("    Hops:        %d redirect(s)\n", len(result.RedirectChain)))
+			report.WriteString(fmt.Sprintf("    Source:      %s\n", result.Source))
+			if result.StoredAs != "" {
+				report.WriteString(fmt.Sprintf("    Stored:      %s\n", result.StoredAs))
+			}
+			report.WriteString(clusterReportLine(result))
 			report.WriteString(fmt.Sprintf("    Discovered:  %s\n", result.Timestamp.Format("2006-01-02 15:04:05")))
 			report.WriteString("\n")
 		}
 	}
 
+	// JavaScript endpoints, grouped by the file they were found in
+	if jsFiles, jsEndpoints := tui.scanner.SortedJSEndpoints(); len(jsFiles) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ JAVASCRIPT ENDPOINTS                                                        │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("Endpoints referenced in discovered JavaScript files. In-scope candidates were\n")
+		report.WriteString("queued for probing; check the findings above for the ones that responded.\n\n")
+
+		for i, file := range jsFiles {
+			endpoints := jsEndpoints[file]
+			report.WriteString(fmt.Sprintf("[%d] FILE: %s (%d endpoints)\n", i+1, file, len(endpoints)))
+			for _, endpoint := range endpoints {
+				report.WriteString(fmt.Sprintf("    - %s\n", endpoint))
+			}
+			report.WriteString("\n")
+		}
+	}
+
 	// Detailed Findings - Redirects (non-200)
 	// Filter to only show redirects that DON'T end in 200 (those are in Redirect200s)
 	nonHitRedirects := []*ScanResult{}
@@ -1887,6 +2430,7 @@ func (tui *TUI) exportExecutiveSummary() {
 		sort.Slice(sortedRedirects, func(i, j int) bool {
 			return sortedRedirects[i].Timestamp.Before(sortedRedirects[j].Timestamp)
 		})
+		sortedRedirects = collapseClusters(sortedRedirects)
 
 		for i, result := range sortedRedirects {
 			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
@@ -1902,11 +2446,33 @@ func (tui *TUI) exportExecutiveSummary() {
 				}
 			}
 
+			report.WriteString(clusterReportLine(result))
 			report.WriteString(fmt.Sprintf("    Discovered:  %s\n", result.Timestamp.Format("2006-01-02 15:04:05")))
 			report.WriteString("\n")
 		}
 	}
 
+	// Latency anomalies, with the baseline of each status class
+	if len(slowResponses) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ SLOW RESPONSE CANDIDATES (MEDIUM PRIORITY)                                  │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("These responses took far longer than others with the same status class,\n")
+		report.WriteString("confirmed by a second request. A slow 404 often means a real handler ran.\n\n")
+		for _, baseline := range tui.scanner.latency.baselines() {
+			report.WriteString(fmt.Sprintf("  %s\n", baseline))
+		}
+		report.WriteString("\n")
+
+		for i, result := range slowResponses {
+			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
+			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.OriginalURL))
+			report.WriteString(fmt.Sprintf("    Status:      %d\n", result.FinalStatus))
+			report.WriteString(fmt.Sprintf("    Time:        %s (class threshold %s)\n", formatLatency(result.ResponseTime), formatLatency(result.SlowThreshold)))
+			report.WriteString("\n")
+		}
+	}
+
 	// Protected Resources
 	if len(tui.scanner.Stats.OtherCodes) > 0 {
 		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
@@ -1918,6 +2484,7 @@ func (tui *TUI) exportExecutiveSummary() {
 		sort.Slice(sortedOther, func(i, j int) bool {
 			return sortedOther[i].Timestamp.Before(sortedOther[j].Timestamp)
 		})
+		sortedOther = collapseClusters(sortedOther)
 
 		for i, result := range sortedOther {
 			statusLabel := "UNKNOWN"
@@ -1932,11 +2499,36 @@ func (tui *TUI) exportExecutiveSummary() {
 			report.WriteString(fmt.Sprintf("[%d] PATH: %s\n", i+1, result.OriginalPath))
 			report.WriteString(fmt.Sprintf("    URL:         %s\n", result.OriginalURL))
 			report.WriteString(fmt.Sprintf("    Status:      %d (%s)\n", result.FinalStatus, statusLabel))
+			report.WriteString(clusterReportLine(result))
 			report.WriteString(fmt.Sprintf("    Discovered:  %s\n", result.Timestamp.Format("2006-01-02 15:04:05")))
 			report.WriteString("\n")
 		}
 	}
 
+	// Response clusters - groups of identical or near-identical responses
+	if clusters := tui.scanner.SortedClusters(); len(clusters) > 0 {
+		report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
+		report.WriteString("│ RESPONSE CLUSTERS                                                           │\n")
+		report.WriteString("└─────────────────────────────────────────────────────────────────────────────┘\n\n")
+		report.WriteString("Paths that returned identical or near-identical content, collapsed to one\n")
+		report.WriteString("representative above. Large clusters are usually soft-404s or catch-alls.\n\n")
+
+		for _, c := range clusters {
+			report.WriteString(fmt.Sprintf("[#%d] %d responses, status %d, e.g. %s\n", c.ID, c.Size(), c.Status, c.Representative.OriginalPath))
+			tui.scanner.Stats.mu.Lock()
+			members := c.Members
+			tui.scanner.Stats.mu.Unlock()
+			for i, m := range members {
+				if i == 10 {
+					report.WriteString(fmt.Sprintf("    ... and %d more\n", len(members)-10))
+					break
+				}
+				report.WriteString(fmt.Sprintf("    - %s\n", m.OriginalPath))
+			}
+			report.WriteString("\n")
+		}
+	}
+
 	// Recommendations
 	report.WriteString("┌─────────────────────────────────────────────────────────────────────────────┐\n")
 	report.WriteString("│ RECOMMENDATIONS                                                              │\n")
@@ -1954,7 +2546,7 @@ func (tui *TUI) exportExecutiveSummary() {
 
 	report.WriteString("3. Verify protected resources:\n")
 	report.WriteString("   - Confirm authentication mechanisms are properly enforced\n")
-	report.WriteString("   - Test for authorization bypass vulnerabilities\n")
+	report.WriteString("   - Test for authorization bypass vulnerabilities (-bypass covers common tricks)\n")
 	report.WriteString("   - Verify 401/403 responses don't leak sensitive information\n\n")
 
 	report.WriteString("4. General security considerations:\n")
@@ -1979,10 +2571,114 @@ func (tui *TUI) exportExecutiveSummary() {
 	// Could show success message in UI
 }
 
+// Fixed F4 options; -ac calibration rules are listed after them
+const configMenuFixedOptions = 12
+
+// F4 options edited as text (Enter to edit) rather than with ◀/▶
+const (
+	configMenuInclude = 9
+	configMenuExclude = 10
+)
+
+// configMenuCount is how many F4 entries can be selected
+func (tui *TUI) configMenuCount() int {
+	if tui.scanner.calibration == nil {
+		return configMenuFixedOptions
+	}
+	return configMenuFixedOptions + len(tui.scanner.calibration.Snapshot())
+}
+
+// handleConfigEditKey edits the include/exclude pattern list in the F4 menu.
+// Enter saves the comma-separated list, Esc leaves it unchanged.
+func (tui *TUI) handleConfigEditKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		patterns := parseStringList(tui.configEditText)
+		include := tui.configMenuSelected == configMenuInclude
+		tui.scanner.setRecursion(func(config *Config) {
+			if include {
+				config.RecursionInclude = patterns
+			} else {
+				config.RecursionExclude = patterns
+			}
+		})
+		tui.configEditMode = false
+	case tcell.KeyEscape:
+		tui.configEditMode = false
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(tui.configEditText) > 0 {
+			tui.configEditText = tui.configEditText[:len(tui.configEditText)-1]
+		}
+	case tcell.KeyCtrlU:
+		tui.configEditText = ""
+	case tcell.KeyRune:
+		tui.configEditText += string(ev.Rune())
+	}
+}
+
 func (tui *TUI) renderConfigMenu() {
-	// Draw semi-transparent overlay effect by drawing a box
+	var rules []*CalibrationRule
+	if tui.scanner.calibration != nil {
+		rules = tui.scanner.calibration.Snapshot()
+	}
+
+	// Recursive mode indicator
+	recursiveStatus := "OFF"
+	if tui.scanner.Config.Recursive {
+		recursiveStatus = "ON"
+	}
+	calibrateStatus := "OFF"
+	if tui.scanner.Config.AutoCalibrate {
+		calibrateStatus = "ON"
+	}
+	budget := "unlimited"
+	if tui.scanner.Config.RecursionBudget > 0 {
+		budget = fmt.Sprintf("%d", tui.scanner.Config.RecursionBudget)
+	}
+	include := strings.Join(tui.scanner.Config.RecursionInclude, ",")
+	if include == "" {
+		include = "(all)"
+	}
+	exclude := strings.Join(tui.scanner.Config.RecursionExclude, ",")
+	if exclude == "" {
+		exclude = "(none)"
+	}
+	if tui.configEditMode {
+		if tui.configMenuSelected == configMenuInclude {
+			include = tui.configEditText + "_"
+		} else {
+			exclude = tui.configEditText + "_"
+		}
+	}
+
+	options := []string{
+		fmt.Sprintf("Concurrency:     %d", tui.scanner.Concurrency),
+		fmt.Sprintf("Rate Limit:      %d req/s  (0 = unlimited)", tui.scanner.Config.RateLimit),
+		fmt.Sprintf("Timeout:         %d seconds", int(tui.scanner.Timeout.Seconds())),
+		fmt.Sprintf("Method:          %s", tui.scanner.Config.Method),
+		fmt.Sprintf("Recursive Mode:  %s  (auto-explore directories)", recursiveStatus),
+		fmt.Sprintf("Recursion Depth: %d  (levels below the base path)", tui.scanner.Config.RecursionDepth),
+		fmt.Sprintf("Recursion Order: %s", strings.ToUpper(tui.scanner.Config.RecursionOrder)),
+		fmt.Sprintf("Recurse On:      %s  (status codes)", formatStatusSet(tui.scanner.Config.RecursionStatuses)),
+		fmt.Sprintf("Dir Budget:      %s  (requests per directory)", budget),
+		fmt.Sprintf("Include:         %s", include),
+		fmt.Sprintf("Exclude:         %s", exclude),
+		fmt.Sprintf("Auto-Calibrate:  %s  (-ac filter rules)", calibrateStatus),
+	}
+
+	// Draw semi-transparent overlay effect by drawing a box. Options are
+	// double-spaced unless that won't fit the terminal.
 	menuWidth := 65
-	menuHeight := 18
+	spacing := 2
+	extra := 0
+	if len(rules) > 0 {
+		extra = 1 + len(rules)
+	}
+	menuHeight := len(options)*spacing + 6 + extra
+	if menuHeight > tui.height-2 {
+		spacing = 1
+		menuHeight = len(options) + 6 + extra
+	}
 	menuX := (tui.width - menuWidth) / 2
 	menuY := (tui.height - menuHeight) / 2
 
@@ -2002,34 +2698,47 @@ func (tui *TUI) renderConfigMenu() {
 	textStyle := tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Text)
 	selectedStyle := tcell.StyleDefault.Background(CurrentTheme.Success).Foreground(CurrentTheme.Background).Bold(true)
 
-	// Recursive mode indicator
-	recursiveStatus := "OFF"
-	if tui.scanner.Config.Recursive {
-		recursiveStatus = "ON"
-	}
-
-	options := []string{
-		fmt.Sprintf("Concurrency:     %d", tui.scanner.Concurrency),
-		fmt.Sprintf("Rate Limit:      %d req/s  (0 = unlimited)", tui.scanner.Config.RateLimit),
-		fmt.Sprintf("Timeout:         %d seconds", int(tui.scanner.Timeout.Seconds())),
-		fmt.Sprintf("Method:          %s", tui.scanner.Config.Method),
-		fmt.Sprintf("Recursive Mode:  %s  (auto-explore directories)", recursiveStatus),
-		fmt.Sprintf("Recursion Depth: %d  (max directory levels)", tui.scanner.Config.RecursionDepth),
-	}
-
 	startY := menuY + 2
 	for i, option := range options {
 		style := textStyle
 		if i == tui.configMenuSelected {
 			style = selectedStyle
-			option = option + " ◀ ▶"
+			if i == configMenuInclude || i == configMenuExclude {
+				if !tui.configEditMode {
+					option = option + " ⏎"
+				}
+			} else {
+				option = option + " ◀ ▶"
+			}
+		}
+		tui.drawText(menuX+2, startY+i*spacing, truncateString(option, menuWidth-4), style)
+	}
+
+	// Calibration rules, one per line, each toggled on its own
+	if len(rules) > 0 {
+		ruleY := startY + len(options)*spacing
+		tui.drawText(menuX+2, ruleY, "Calibration filters:", textStyle.Dim(true))
+		for i, rule := range rules {
+			mark := "[x]"
+			if !rule.Enabled {
+				mark = "[ ]"
+			}
+			option := truncateString(fmt.Sprintf("%s %s - %d filtered", mark, rule, rule.Hits()), menuWidth-8)
+			style := textStyle
+			if configMenuFixedOptions+i == tui.configMenuSelected {
+				style = selectedStyle
+				option = option + " ◀ ▶"
+			}
+			tui.drawText(menuX+4, ruleY+1+i, option, style)
 		}
-		tui.drawText(menuX+2, startY+i*2, option, style)
 	}
 
 	// Instructions
 	instrY := menuY + menuHeight - 3
-	instr := "↑/↓: Navigate | ◀/▶: Change Value | F4/Esc: Close"
+	instr := "↑/↓: Navigate | ◀/▶: Change | Enter: Edit | F4/Esc: Close"
+	if tui.configEditMode {
+		instr = "Comma-separated globs | Enter: Save | Esc: Cancel"
+	}
 	tui.drawText(menuX+2, instrY, instr, tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Info))
 }
 
@@ -2133,11 +2842,11 @@ func (tui *TUI) renderHelpScreen() {
 
 	if line >= minVisibleLine && line <= maxVisibleLine {
 		tui.drawText(col, line, "Wordlist:", labelStyle)
-		tui.drawText(col+18, line, "File containing paths to scan (one per line)", textStyle)
+		tui.drawText(col+18, line, "File of paths (one per line) or a {range:1-100} generator", textStyle)
 	}
 	line += 1
 	if line >= minVisibleLine && line <= maxVisibleLine {
-		tui.drawText(col+18, line, "Default: wordlist.txt in current directory", tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Text).Dim(true))
+		tui.drawText(col+18, line, "Default: built-in list, -category to pick sections", tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Text).Dim(true))
 	}
 	line += 2
 
@@ -2228,6 +2937,21 @@ func (tui *TUI) renderHelpScreen() {
 		tui.drawText(col+12, line, "Scroll this help screen (Up/Down arrow keys)", textStyle)
 	}
 	line += 1
+	if line >= minVisibleLine && line <= maxVisibleLine {
+		tui.drawText(col, line, "Tab:", labelStyle)
+		tui.drawText(col+12, line, "Select a live result (Shift+Tab moves back)", textStyle)
+	}
+	line += 1
+	if line >= minVisibleLine && line <= maxVisibleLine {
+		tui.drawText(col, line, "O:", labelStyle)
+		tui.drawText(col+12, line, "Open the selected result's stored response (needs -store <dir>)", textStyle)
+	}
+	line += 1
+	if line >= minVisibleLine && line <= maxVisibleLine {
+		tui.drawText(col, line, "N:", labelStyle)
+		tui.drawText(col+12, line, "Mark the selected result's cluster as noise (drops every similar response)", textStyle)
+	}
+	line += 1
 	if line >= minVisibleLine && line <= maxVisibleLine {
 		tui.drawText(col, line, "?:", labelStyle)
 		tui.drawText(col+12, line, "Toggle this help screen (alternative to F1)", textStyle)
@@ -2278,19 +3002,44 @@ func (tui *TUI) HandleInput() {
 		ev := tui.screen.PollEvent()
 		switch ev := ev.(type) {
 		case *tcell.EventKey:
+			// The response viewer takes its keys before anything else
+			if tui.showResponseViewer && tui.handleViewerKey(ev) {
+				tui.Render()
+				continue
+			}
+
+			// A text option being edited takes every key until Enter or Esc
+			if tui.showConfigMenu && tui.configEditMode {
+				tui.handleConfigEditKey(ev)
+				tui.Render()
+				continue
+			}
+
 			// Handle config menu navigation first
 			if tui.showConfigMenu {
 				switch ev.Key() {
+				case tcell.KeyEnter:
+					// Start editing a text option with its current value
+					switch tui.configMenuSelected {
+					case configMenuInclude:
+						tui.configEditText = strings.Join(tui.scanner.Config.RecursionInclude, ",")
+						tui.configEditMode = true
+					case configMenuExclude:
+						tui.configEditText = strings.Join(tui.scanner.Config.RecursionExclude, ",")
+						tui.configEditMode = true
+					}
+					tui.Render()
+					continue
 				case tcell.KeyUp:
 					tui.configMenuSelected--
 					if tui.configMenuSelected < 0 {
-						tui.configMenuSelected = 5 // 6 options, so max index is 5
+						tui.configMenuSelected = tui.configMenuCount() - 1
 					}
 					tui.Render()
 					continue
 				case tcell.KeyDown:
 					tui.configMenuSelected++
-					if tui.configMenuSelected > 5 {
+					if tui.configMenuSelected >= tui.configMenuCount() {
 						tui.configMenuSelected = 0
 					}
 					tui.Render()
@@ -2336,10 +3085,38 @@ func (tui *TUI) HandleInput() {
 						}
 						tui.scanner.Config.Method = methods[currentIdx]
 					case 4: // Recursive Mode (toggle)
-						tui.scanner.Config.Recursive = !tui.scanner.Config.Recursive
+						tui.scanner.setRecursion(func(config *Config) { config.Recursive = !config.Recursive })
 					case 5: // Recursion Depth
-						if tui.scanner.Config.RecursionDepth > 1 {
-							tui.scanner.Config.RecursionDepth--
+						tui.scanner.setRecursion(func(config *Config) {
+							if config.RecursionDepth > 1 {
+								config.RecursionDepth--
+							}
+						})
+					case 6: // Recursion Order (toggle)
+						tui.scanner.setRecursion(func(config *Config) {
+							if config.RecursionOrder == OrderDFS {
+								config.RecursionOrder = OrderBFS
+							} else {
+								config.RecursionOrder = OrderDFS
+							}
+						})
+						if tui.scanner.queue != nil {
+							tui.scanner.queue.SetOrder(tui.scanner.Config.RecursionOrder)
+						}
+					case 7: // Recurse On Status
+						tui.scanner.setRecursion(func(config *Config) {
+							config.RecursionStatuses = cycleStatusPreset(config.RecursionStatuses, -1)
+						})
+					case 8: // Dir Budget
+						tui.scanner.setRecursion(func(config *Config) {
+							config.RecursionBudget = cycleBudgetPreset(config.RecursionBudget, -1)
+						})
+					case configMenuInclude, configMenuExclude: // Text, edited with Enter
+					case 11: // Auto-Calibrate (toggle)
+						tui.scanner.Config.AutoCalibrate = !tui.scanner.Config.AutoCalibrate
+					default: // Calibration rule (toggle)
+						if tui.scanner.calibration != nil {
+							tui.scanner.calibration.Toggle(tui.configMenuSelected - configMenuFixedOptions)
 						}
 					}
 					tui.Render()
@@ -2379,11 +3156,39 @@ func (tui *TUI) HandleInput() {
 						}
 						tui.scanner.Config.Method = methods[currentIdx]
 					case 4: // Recursive Mode (toggle)
-						tui.scanner.Config.Recursive = !tui.scanner.Config.Recursive
+						tui.scanner.setRecursion(func(config *Config) { config.Recursive = !config.Recursive })
 					case 5: // Recursion Depth
-						tui.scanner.Config.RecursionDepth++
-						if tui.scanner.Config.RecursionDepth > 10 {
-							tui.scanner.Config.RecursionDepth = 10
+						tui.scanner.setRecursion(func(config *Config) {
+							config.RecursionDepth++
+							if config.RecursionDepth > 10 {
+								config.RecursionDepth = 10
+							}
+						})
+					case 6: // Recursion Order (toggle)
+						tui.scanner.setRecursion(func(config *Config) {
+							if config.RecursionOrder == OrderDFS {
+								config.RecursionOrder = OrderBFS
+							} else {
+								config.RecursionOrder = OrderDFS
+							}
+						})
+						if tui.scanner.queue != nil {
+							tui.scanner.queue.SetOrder(tui.scanner.Config.RecursionOrder)
+						}
+					case 7: // Recurse On Status
+						tui.scanner.setRecursion(func(config *Config) {
+							config.RecursionStatuses = cycleStatusPreset(config.RecursionStatuses, 1)
+						})
+					case 8: // Dir Budget
+						tui.scanner.setRecursion(func(config *Config) {
+							config.RecursionBudget = cycleBudgetPreset(config.RecursionBudget, 1)
+						})
+					case configMenuInclude, configMenuExclude: // Text, edited with Enter
+					case 11: // Auto-Calibrate (toggle)
+						tui.scanner.Config.AutoCalibrate = !tui.scanner.Config.AutoCalibrate
+					default: // Calibration rule (toggle)
+						if tui.scanner.calibration != nil {
+							tui.scanner.calibration.Toggle(tui.configMenuSelected - configMenuFixedOptions)
 						}
 					}
 					tui.Render()
@@ -2433,6 +3238,15 @@ func (tui *TUI) HandleInput() {
 				}
 				tui.running = false
 				return
+			case tcell.KeyTab, tcell.KeyBacktab:
+				// Tab / Shift+Tab - Select a live result (to open with O)
+				if !tui.showConfigMenu && !tui.showHelpScreen && !tui.inputActive {
+					if ev.Key() == tcell.KeyTab {
+						tui.moveResultSelection(1)
+					} else {
+						tui.moveResultSelection(-1)
+					}
+				}
 			case tcell.KeyF1:
 				// F1 - Toggle Help Screen (INDUSTRY STANDARD)
 				tui.showHelpScreen = !tui.showHelpScreen
@@ -2507,6 +3321,17 @@ func (tui *TUI) HandleInput() {
 					case '?':
 						// Toggle help screen (alternative to F1)
 						tui.showHelpScreen = !tui.showHelpScreen
+					case 'o', 'O':
+						// Open the selected result's stored response
+						if !tui.showConfigMenu && !tui.showHelpScreen {
+							tui.openResponseViewer()
+						}
+					case 'n', 'N':
+						// Mark the selected result's cluster as noise, dropping all its members
+						if tui.selectedResult != nil && tui.selectedResult.cluster != nil {
+							tui.scanner.MarkClusterNoise(tui.selectedResult.cluster)
+							tui.selectedResult = nil
+						}
 					case '`':
 						// Backtick - Cycle through themes
 						tui.cycleTheme(' ')
@@ -2695,10 +3520,7 @@ func (tui *TUI) submitInput() {
 	tui.scanner.cancelMutex.Unlock()
 
 	// Reset statistics
-	tui.scanner.Stats = &Statistics{
-		RedirectTargets: make(map[string]int),
-		ContentHashes:   make(map[string][]*ScanResult),
-	}
+	tui.scanner.Stats = NewStatistics()
 	tui.scanner.LiveStats = &LiveStats{
 		StartTime:  time.Now(),
 		EndTime:    time.Time{}, // Reset to zero (scan not finished)
@@ -2709,21 +3531,18 @@ func (tui *TUI) submitInput() {
 	// Reset visited paths for new scan
 	tui.scanner.pathMutex.Lock()
 	tui.scanner.visitedPaths = make(map[string]bool)
+	tui.scanner.pathSources = make(map[string]string)
+	tui.scanner.variantOf = make(map[string]*ScanResult)
+	tui.scanner.dirBaselines = make(map[string]*WildcardBaseline)
+	tui.scanner.redirectTried = make(map[string]bool)
+	tui.scanner.bypassBaselines = make(map[string]string)
+	tui.scanner.profile = ServerProfile{}
+	tui.scanner.latency = newLatencyProfile()
+	tui.scanner.calibration = nil
 	tui.scanner.pathMutex.Unlock()
 
-	// Drain and reset recursion queue
-	draining := true
-	for draining {
-		select {
-		case <-tui.scanner.recursionQueue:
-			// Discard old queue items
-		default:
-			draining = false
-		}
-	}
-
-	// Load wordlist - try default
-	paths, err := LoadWordlist("wordlist.txt")
+	// Load wordlist - -wordlist file or the built-in list
+	paths, err := LoadScanWordlist(tui.scanner.Config)
 	if err != nil {
 		// Can't start scan without wordlist
 		return
@@ -2766,31 +3585,43 @@ func NewScanner(baseURL string, concurrency int, timeout int, verbose bool, conf
 		rateLimiter = time.Tick(interval)
 	}
 
-	recursionQueue := make(chan string, 10000)
-
 	return &Scanner{
-		BaseURL:        strings.TrimRight(baseURL, "/"),
-		Concurrency:    concurrency,
-		Timeout:        time.Duration(timeout) * time.Second,
-		Verbose:        verbose,
-		Client:         client,
-		Config:         config,
-		visitedPaths:   make(map[string]bool),
-		recursionQueue: recursionQueue,
-		rateLimiter:    rateLimiter,
-		lastResults:    make([]*ScanResult, 0, 50),
+		BaseURL:         strings.TrimRight(baseURL, "/"),
+		Concurrency:     concurrency,
+		Timeout:         time.Duration(timeout) * time.Second,
+		Verbose:         verbose,
+		Client:          client,
+		Config:          config,
+		visitedPaths:    make(map[string]bool),
+		pathSources:     make(map[string]string),
+		variantOf:       make(map[string]*ScanResult),
+		dirBaselines:    make(map[string]*WildcardBaseline),
+		redirectTried:   make(map[string]bool),
+		bypassBaselines: make(map[string]string),
+		latency:         newLatencyProfile(),
+		rateLimiter:     rateLimiter,
+		lastResults:     make([]*ScanResult, 0, 50),
 		LiveStats: &LiveStats{
 			StartTime:  time.Now(),
 			LastUpdate: time.Now(),
 		},
-		Stats: &Statistics{
-			RedirectTargets: make(map[string]int),
-			ContentHashes:   make(map[string][]*ScanResult),
-		},
+		Stats: NewStatistics(),
 	}
 }
 
+// requestOptions overrides how a single request is sent. The zero value
+// sends the configured method with the configured headers.
+type requestOptions struct {
+	Method   string
+	Headers  map[string]string
+	NoFollow bool // Return the first response, even if it is a redirect
+}
+
 func (s *Scanner) FetchWithRedirectTracking(targetURL string) (*ScanResult, error) {
+	return s.fetchWithOptions(targetURL, requestOptions{})
+}
+
+func (s *Scanner) fetchWithOptions(targetURL string, opts requestOptions) (*ScanResult, error) {
 	if s.rateLimiter != nil {
 		<-s.rateLimiter
 	}
@@ -2804,6 +3635,9 @@ func (s *Scanner) FetchWithRedirectTracking(targetURL string) (*ScanResult, erro
 	startTime := time.Now()
 
 	method := s.Config.Method
+	if opts.Method != "" {
+		method = opts.Method
+	}
 	if method == "" {
 		method = "GET"
 	}
@@ -2823,6 +3657,10 @@ func (s *Scanner) FetchWithRedirectTracking(targetURL string) (*ScanResult, erro
 			req.Header.Set("Cookie", s.Config.Cookie)
 		}
 
+		for key, value := range opts.Headers {
+			req.Header.Set(key, value)
+		}
+
 		resp, err := s.Client.Do(req)
 		if err != nil {
 			return nil, err
@@ -2833,7 +3671,7 @@ func (s *Scanner) FetchWithRedirectTracking(targetURL string) (*ScanResult, erro
 
 		status := resp.StatusCode
 
-		if status >= 300 && status < 400 {
+		if status >= 300 && status < 400 && !opts.NoFollow {
 			location := resp.Header.Get("Location")
 			if location == "" {
 				break
@@ -2865,10 +3703,16 @@ func (s *Scanner) FetchWithRedirectTracking(targetURL string) (*ScanResult, erro
 			RedirectChain: redirectChain,
 			ContentLength: len(body),
 			ContentHash:   contentHash,
+			Words:         countWords(body),
+			Lines:         countLines(body),
 			IsDirect200:   isDirect,
 			ResponseTime:  responseTime,
 			Timestamp:     time.Now(),
+			header:        resp.Header,
+			body:          body,
+			response:      resp,
 		}
+		captureResponseMetadata(result, resp.Header, body, s.Config.KeepHeaders)
 
 		return result, nil
 	}
@@ -2915,6 +3759,9 @@ func (s *Scanner) DetectWildcard() *WildcardBaseline {
 }
 
 func (s *Scanner) IsWildcardResponse(result *ScanResult) bool {
+	if s.isDirectoryWildcard(result) {
+		return true
+	}
 	if s.WildcardBaseline == nil {
 		return false
 	}
@@ -2951,7 +3798,26 @@ func (s *Scanner) ShouldFilterResult(result *ScanResult) bool {
 		}
 	}
 
-	return false
+	if len(s.Config.MatchHeaders) > 0 {
+		found := false
+		for _, rule := range s.Config.MatchHeaders {
+			if rule.Matches(result.header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+
+	for _, rule := range s.Config.FilterHeaders {
+		if rule.Matches(result.header) {
+			return true
+		}
+	}
+
+	return s.calibrationFiltered(result)
 }
 
 func (s *Scanner) ScanPath(path string) (*ScanResult, error) {
@@ -2963,14 +3829,40 @@ func (s *Scanner) ScanPath(path string) (*ScanResult, error) {
 		return nil, err
 	}
 
+	result.Source = s.pathSource(path)
+
+	// Timing is checked before any filtering: a slow 404 or catch-all page
+	// can be a real handler, and is shown even though the result is dropped
+	s.checkLatency(result)
+
+	if parent := s.variantParent(path); parent != nil {
+		// A variant serving the same content as its original just means the
+		// server ignores the suffix - not a leftover file
+		if result.FinalStatus == parent.FinalStatus && result.ContentHash == parent.ContentHash {
+			s.showSlowCandidate(result)
+			return nil, nil
+		}
+		result.VariantOf = parent.OriginalPath
+	}
+
 	if s.IsWildcardResponse(result) {
+		s.showSlowCandidate(result)
 		return nil, nil
 	}
 
 	if s.ShouldFilterResult(result) {
+		s.showSlowCandidate(result)
+		return nil, nil
+	}
+
+	// Anything matching a cluster marked as noise is dropped like a filter
+	if s.clusterResult(result) {
+		s.showSlowCandidate(result)
 		return nil, nil
 	}
 
+	s.analyzeResult(result)
+
 	// Update live stats
 	s.Stats.mu.Lock()
 	s.Stats.TotalScanned++
@@ -3003,126 +3895,141 @@ func (s *Scanner) ScanPath(path string) (*ScanResult, error) {
 	// Add to live display buffer
 	s.AddLiveResult(result)
 
-	// RECURSIVE AUTO-COMPLETE: If this looks like a valid directory, queue recursive scans
-	if s.Config.Recursive && isLikelyDirectory(path) {
-		// Queue recursive paths for: 200 OK, 301/302 redirects (often directories), 403 (might have accessible subdirs)
-		if result.FinalStatus == 200 || result.FinalStatus == 301 || result.FinalStatus == 302 || result.FinalStatus == 403 {
-			// Signal that we found a directory to explore (will be processed by ScanAll)
-			select {
-			case s.recursionQueue <- "DIR:" + path:
-			default:
-			}
-		}
+	return result, nil
+}
+
+// analyzeResult runs the response analyzers over a kept result while its
+// headers and body are still in memory, then releases them so long scans
+// don't hold every response body in RAM.
+func (s *Scanner) analyzeResult(result *ScanResult) {
+	if s.Config.Profile {
+		s.profileFromHit(result)
+	}
+	s.detectTechnologies(result)
+	if s.Config.AutoExtensions {
+		s.inferExtensions(result)
 	}
+	s.detectSensitive(result)
+	s.listingResult(result)
+	s.classifyResult(result)
+	if s.Config.Crawl {
+		s.crawlResult(result)
+	}
+	if s.Config.ExtractJS {
+		s.extractJSResult(result)
+	}
+	if s.Config.Backups {
+		s.backupResult(result)
+	}
+	if s.Config.RedirectCheck {
+		s.checkOpenRedirect(result)
+	}
+	if s.Config.Bypass {
+		s.checkBypass(result)
+	}
+	if s.Config.Harvest {
+		s.harvestResult(result)
+	}
+	s.storeResult(result)
 
-	return result, nil
+	result.header = nil
+	result.body = nil
+	result.response = nil
 }
 
 func (s *Scanner) AddLiveResult(result *ScanResult) {
 	s.resultsMutex.Lock()
 	defer s.resultsMutex.Unlock()
 
+	// Near-duplicates collapse into the line already showing their cluster,
+	// unless their timing stands out
+	if result.cluster != nil && result.SlowThreshold == 0 {
+		for _, shown := range s.lastResults {
+			if shown.cluster == result.cluster {
+				return
+			}
+		}
+	}
+
 	s.lastResults = append(s.lastResults, result)
 	if len(s.lastResults) > 100 {
 		s.lastResults = s.lastResults[len(s.lastResults)-100:]
 	}
 }
 
-// isLikelyDirectory checks if a path looks like a directory (no file extension)
-func isLikelyDirectory(path string) bool {
-	// Remove trailing slash for analysis
-	cleanPath := strings.TrimSuffix(path, "/")
-
-	// Get the last segment
-	parts := strings.Split(cleanPath, "/")
-	if len(parts) == 0 {
-		return true
-	}
-	lastPart := parts[len(parts)-1]
+func (s *Scanner) ScanAll(paths []string, tui *TUI) []*ScanResult {
+	// Mutation rules expand the wordlist before anything else, so recursion
+	// and extensions use the expanded list too
+	paths = s.expandWordlist(paths)
+	paths = s.reorderByHistory(paths)
 
-	// Check if it has a file extension (contains a dot followed by 2-5 chars)
-	if strings.Contains(lastPart, ".") {
-		ext := strings.ToLower(lastPart[strings.LastIndex(lastPart, ".")+1:])
-		// Common file extensions - if it matches, it's likely a file not a directory
-		fileExts := map[string]bool{
-			"html": true, "htm": true, "php": true, "asp": true, "aspx": true,
-			"jsp": true, "js": true, "css": true, "json": true, "xml": true,
-			"txt": true, "csv": true, "pdf": true, "doc": true, "docx": true,
-			"xls": true, "xlsx": true, "png": true, "jpg": true, "jpeg": true,
-			"gif": true, "svg": true, "ico": true, "zip": true, "tar": true,
-			"gz": true, "rar": true, "7z": true, "sql": true, "bak": true,
-			"log": true, "yml": true, "yaml": true, "md": true, "py": true,
-			"rb": true, "go": true, "java": true, "class": true, "jar": true,
-			"war": true, "ear": true, "dll": true, "exe": true, "so": true,
-			"sh": true, "bat": true, "ps1": true, "env": true, "config": true,
-		}
-		if fileExts[ext] {
-			return false
-		}
-	}
-	return true
-}
+	// Store original wordlist for recursive scanning
+	s.recursionWords = make([]string, len(paths))
+	copy(s.recursionWords, paths)
 
-// QueueRecursivePaths adds new paths to scan based on a discovered directory
-func (s *Scanner) QueueRecursivePaths(basePath string, wordlist []string) int {
-	if !s.Config.Recursive {
-		return 0
+	if len(s.Config.Extensions) > 0 {
+		paths = GeneratePathsWithExtensions(paths, s.Config.Extensions)
 	}
 
-	// Check recursion depth
-	depth := strings.Count(basePath, "/")
-	if depth >= s.Config.RecursionDepth {
-		return 0
+	s.harvest, s.harvestQueued, s.harvestFile, s.harvestErr = nil, 0, "", nil
+	if s.Config.Harvest {
+		s.harvest = newWordHarvester()
 	}
 
-	queued := 0
-	basePath = strings.TrimSuffix(basePath, "/")
-
-	for _, word := range wordlist {
-		newPath := basePath + "/" + strings.TrimPrefix(word, "/")
+	// Wildcard detection
+	s.WildcardBaseline = s.DetectWildcard()
 
-		// Check if we've already visited this path
-		s.pathMutex.Lock()
-		if s.visitedPaths[newPath] {
-			s.pathMutex.Unlock()
-			continue
-		}
-		s.visitedPaths[newPath] = true
-		s.pathMutex.Unlock()
+	// Extension inference: fingerprint / so an obvious stack gets its
+	// extensions before the wordlist is queued
+	s.extensions = nil
+	if s.Config.AutoExtensions {
+		paths = append(paths, extensionVariants(s.recursionWords, s.inferExtensionsFromRoot())...)
+	}
 
-		// Try to queue the path (non-blocking)
-		select {
-		case s.recursionQueue <- newPath:
-			queued++
-		default:
-			// Queue is full, skip
-		}
+	// Auto-calibration: filter rules from how the server answers nonsense
+	if s.Config.AutoCalibrate {
+		s.calibration = s.AutoCalibrate()
 	}
 
-	return queued
-}
+	// Calibration: learn how the server normalizes paths, so wordlist entries
+	// it would treat as the same path are only requested once
+	if s.Config.Profile {
+		s.calibrateProfile()
+	}
 
-func (s *Scanner) ScanAll(paths []string, tui *TUI) []*ScanResult {
-	// Store original wordlist for recursive scanning
-	originalWordlist := make([]string, len(paths))
-	copy(originalWordlist, paths)
+	// Crawling and other discovery push onto the same queue while the scan
+	// runs
+	s.recursionMutex.RLock()
+	s.queue = newScanQueue(s.Config.RecursionOrder)
+	s.recursionMutex.RUnlock()
+	atomic.StoreInt64(&s.crawlQueued, 0)
+	atomic.StoreInt64(&s.LiveStats.TotalRequests, 0)
 
-	if len(s.Config.Extensions) > 0 {
-		paths = GeneratePathsWithExtensions(paths, s.Config.Extensions)
+	// Pre-scan phase: robots.txt, sitemaps and .well-known files are queued
+	// ahead of the wordlist, and before it is marked visited so a path
+	// both mention keeps its seed source
+	if s.Config.Seed {
+		seeds := s.SeedFromWellKnown()
+		s.Stats.mu.Lock()
+		s.Stats.Seeds = seeds
+		s.Stats.mu.Unlock()
 	}
 
-	// Mark initial paths as visited
+	// Mark initial paths as visited and queue them
+	var initial []QueuedPath
 	for _, p := range paths {
-		s.pathMutex.Lock()
-		s.visitedPaths[p] = true
-		s.pathMutex.Unlock()
+		if !s.isExcluded(p) && s.markVisited(p) {
+			initial = append(initial, QueuedPath{Path: p, Source: SourceWordlist})
+		}
 	}
+	atomic.AddInt64(&s.LiveStats.TotalRequests, int64(len(initial)))
+	s.queue.Push(initial...)
 
-	totalPaths := len(paths)
-	s.LiveStats.TotalRequests = int64(totalPaths)
-
-	// Wildcard detection
-	s.WildcardBaseline = s.DetectWildcard()
+	// A -wordlist generator is streamed onto the queue as the workers catch
+	// up, rather than loaded up front
+	if s.Config.Generator != nil {
+		s.streamGenerator(s.Config.Generator)
+	}
 
 	// Start speed calculator
 	speedDone := make(chan bool)
@@ -3135,167 +4042,87 @@ func (s *Scanner) ScanAll(paths []string, tui *TUI) []*ScanResult {
 			case <-speedDone:
 				return
 			case <-ticker.C:
+				// Keeps running until the scan ends, since a second pass
+				// can add work after the first one completes
 				completed := atomic.LoadInt64(&s.LiveStats.CompletedRequests)
-				total := atomic.LoadInt64(&s.LiveStats.TotalRequests)
 				elapsed := time.Since(s.LiveStats.StartTime).Seconds()
 				if elapsed > 0 {
 					s.LiveStats.mu.Lock()
 					s.LiveStats.CurrentSpeed = float64(completed) / elapsed
 					s.LiveStats.mu.Unlock()
 				}
-
-				if completed >= total {
-					return
-				}
 			}
 		}
 	}()
 
 	// Scanning
-	sem := make(chan struct{}, s.Concurrency)
-	var wg sync.WaitGroup
-
 	var results []*ScanResult
 	var resultsMutex sync.Mutex
 
-	// RECURSIVE SCANNING: Start a goroutine to process discovered directories
-	recursiveDone := make(chan bool)
-	if s.Config.Recursive {
-		go func() {
-			for {
-				select {
-				case <-recursiveDone:
-					return
-				case dirSignal := <-s.recursionQueue:
-					// Check for cancellation
+	// Workers pull from the frontier until it drains. A directory hit is
+	// expanded before its item is marked done, so the frontier can't drain
+	// while children are still to be pushed, and nothing is ever dropped.
+	runWorkers := func() {
+		var workers sync.WaitGroup
+		for i := 0; i < s.Concurrency; i++ {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for {
+					item, ok := s.queue.Pop()
+					if !ok {
+						return
+					}
+
+					// Check if scan has been cancelled
 					s.cancelMutex.Lock()
 					cancelled := s.cancelScan
 					s.cancelMutex.Unlock()
+
 					if cancelled {
+						// Mark remaining requests as completed so progress shows 100%
+						dropped := s.queue.Clear()
+						atomic.AddInt64(&s.LiveStats.CompletedRequests, int64(dropped)+1)
+						s.queue.Done(item)
 						continue
 					}
 
-					// Process directory signal
-					if strings.HasPrefix(dirSignal, "DIR:") {
-						basePath := strings.TrimPrefix(dirSignal, "DIR:")
+					result, _ := s.ScanPath(item.Path)
 
-						// Generate new paths by appending wordlist to this directory
-						newPaths := []string{}
-						for _, word := range originalWordlist {
-							newPath := strings.TrimSuffix(basePath, "/") + "/" + strings.TrimPrefix(word, "/")
+					if result != nil {
+						resultsMutex.Lock()
+						results = append(results, result)
+						resultsMutex.Unlock()
 
-							// Skip if already visited
-							s.pathMutex.Lock()
-							if s.visitedPaths[newPath] {
-								s.pathMutex.Unlock()
-								continue
-							}
-							s.visitedPaths[newPath] = true
-							s.pathMutex.Unlock()
-
-							// Check depth limit
-							depth := strings.Count(newPath, "/")
-							if depth <= s.Config.RecursionDepth {
-								newPaths = append(newPaths, newPath)
-							}
-						}
-
-						// Update total and scan new paths
-						if len(newPaths) > 0 {
-							atomic.AddInt64(&s.LiveStats.TotalRequests, int64(len(newPaths)))
-
-							for _, p := range newPaths {
-								wg.Add(1)
-								go func(path string) {
-									defer wg.Done()
-									sem <- struct{}{}
-									defer func() { <-sem }()
-
-									s.cancelMutex.Lock()
-									cancelled := s.cancelScan
-									s.cancelMutex.Unlock()
-
-									if cancelled {
-										atomic.AddInt64(&s.LiveStats.CompletedRequests, 1)
-										return
-									}
-
-									result, _ := s.ScanPath(path)
-									if result != nil {
-										resultsMutex.Lock()
-										results = append(results, result)
-										resultsMutex.Unlock()
-									}
-
-									atomic.AddInt64(&s.LiveStats.CompletedRequests, 1)
-								}(p)
-							}
+						// RECURSIVE AUTO-COMPLETE: the response showed this is a
+						// directory, so its children go onto the frontier
+						if s.shouldRecurse(result) {
+							s.expandDirectory(item)
 						}
 					}
-				default:
-					// No directory signals, brief sleep to prevent CPU spin
-					time.Sleep(50 * time.Millisecond)
-				}
-			}
-		}()
-	}
 
-	for _, p := range paths {
-		// Check if scan has been cancelled
-		s.cancelMutex.Lock()
-		cancelled := s.cancelScan
-		s.cancelMutex.Unlock()
-
-		if cancelled {
-			// Mark remaining requests as completed so progress shows 100%
-			atomic.AddInt64(&s.LiveStats.CompletedRequests, int64(totalPaths)-atomic.LoadInt64(&s.LiveStats.CompletedRequests))
-			break
+					atomic.AddInt64(&s.LiveStats.CompletedRequests, 1)
+					s.queue.Done(item)
+				}
+			}()
 		}
-
-		wg.Add(1)
-		go func(path string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			// Check cancellation before processing
-			s.cancelMutex.Lock()
-			cancelled := s.cancelScan
-			s.cancelMutex.Unlock()
-
-			if cancelled {
-				atomic.AddInt64(&s.LiveStats.CompletedRequests, 1)
-				return
-			}
-
-			result, _ := s.ScanPath(path)
-			if result != nil {
-				resultsMutex.Lock()
-				results = append(results, result)
-				resultsMutex.Unlock()
-			}
-
-			atomic.AddInt64(&s.LiveStats.CompletedRequests, 1)
-		}(p)
+		workers.Wait()
 	}
+	runWorkers()
 
-	wg.Wait()
-
-	// Give recursive scanner a moment to finish any pending work
-	if s.Config.Recursive {
-		time.Sleep(200 * time.Millisecond)
-		// Drain any remaining items and wait for those to complete
-		draining := true
-		for draining {
-			select {
-			case <-s.recursionQueue:
-				// Discard remaining queue items after main scan is done
-			default:
-				draining = false
-			}
-		}
-		close(recursiveDone)
+	// Second pass: words harvested from the first pass's responses
+	s.cancelMutex.Lock()
+	cancelled := s.cancelScan
+	s.cancelMutex.Unlock()
+	if s.Config.HarvestPass && !cancelled && s.queueHarvested() > 0 {
+		runWorkers()
 	}
+	// Clusters marked as noise mid-scan are out of the results, so they
+	// don't count as history hits either
+	results = s.withoutNoise(results)
+	s.saveHarvest()
+	s.recordHistory(results)
+
 	close(speedDone)
 
 	return results
@@ -3433,21 +4260,12 @@ func LoadWordlist(path string) ([]string, error) {
 	}
 	defer file.Close()
 
-	var paths []string
 	data, err := io.ReadAll(file)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" && !strings.HasPrefix(line, "#") {
-			paths = append(paths, line)
-		}
-	}
-
-	return paths, nil
+	return parseWordlist(string(data)), nil
 }
 
 func ExportToJSON(filename string, results []*ScanResult) error {
@@ -3472,7 +4290,7 @@ func ExportToCSV(filename string, results []*ScanResult) error {
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	header := []string{"Path", "URL", "Status", "Final URL", "Redirects", "Length", "Hash", "Direct200", "Time(ms)"}
+	header := []string{"Path", "URL", "Status", "Final URL", "Redirects", "Length", "Hash", "Direct200", "Time(ms)", "Title", "Server", "Content-Type", "X-Powered-By", "Headers", "Source", "Findings", "Variant Of", "Listing", "Kind", "Stored", "Open Redirect", "Bypass", "Slow", "Words", "Lines"}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
@@ -3494,6 +4312,22 @@ func ExportToCSV(filename string, results []*ScanResult) error {
 			result.ContentHash[:12],
 			direct200,
 			strconv.FormatInt(result.ResponseTime.Milliseconds(), 10),
+			result.Title,
+			result.Server,
+			result.ContentType,
+			result.PoweredBy,
+			formatKeptHeaders(result.Headers),
+			result.Source,
+			formatFindings(result.Findings),
+			result.VariantOf,
+			result.Listing,
+			string(result.Kind),
+			result.StoredAs,
+			openRedirectPayload(result),
+			formatBypasses(result.Bypasses),
+			slowThreshold(result),
+			strconv.Itoa(result.Words),
+			strconv.Itoa(result.Lines),
 		}
 		if err := writer.Write(row); err != nil {
 			return err
@@ -3588,23 +4422,56 @@ func parseStringList(s string) []string {
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
+	// "pathfinder wordlist ..." runs a maintenance command instead of a scan
+	if len(os.Args) > 1 && os.Args[1] == "wordlist" {
+		os.Exit(runWordlistCommand(os.Args[2:]))
+	}
+
 	target := flag.String("target", "", "Target base URL")
-	wordlist := flag.String("wordlist", "wordlist.txt", "Wordlist file")
-	_ = wordlist // Keep flag for future use, loaded dynamically when scan starts
+	wordlist := flag.String("wordlist", "", "Wordlist file or generator, e.g. invoice/{range:1-5000} (default: the built-in list)")
+	category := flag.String("category", "", "Only scan these wordlist sections (comma-separated: "+strings.Join(WordlistCategoryNames(), ", ")+")")
 	concurrency := flag.Int("concurrency", DefaultConcurrency, "Concurrent requests")
 	timeout := flag.Int("timeout", DefaultTimeout, "Timeout in seconds")
 	verbose := flag.Bool("verbose", false, "Verbose output")
 	statusCodes := flag.String("mc", "", "Match status codes")
 	filterStatuses := flag.String("fc", "", "Filter status codes")
 	filterSizes := flag.String("fs", "", "Filter content sizes")
+	var matchHeaders, filterHeaders headerRuleFlag
+	flag.Var(&matchHeaders, "mh", "Match header (Name: regex), repeatable")
+	flag.Var(&filterHeaders, "fh", "Filter header (Name: regex), repeatable")
+	keepHeaders := flag.String("keep-headers", "", "Extra response headers to keep (comma-separated)")
 	extensions := flag.String("x", "", "File extensions")
+	autoExtensions := flag.Bool("auto-x", false, "Pick extensions from the detected stack (headers, cookies, early hits)")
+	rulesFile := flag.String("rules", "", "Wordlist mutation rules file (case, separators, affixes, years, plurals)")
 	headers := flag.String("H", "", "Custom header")
 	cookie := flag.String("cookie", "", "Cookie data")
 	method := flag.String("X", "GET", "HTTP method")
 	rateLimit := flag.Int("rate", 0, "Max requests/sec")
 	delay := flag.Int("delay", 0, "Delay between requests (ms)")
+	crawl := flag.Bool("crawl", false, "Extract links from hits and queue in-scope paths")
+	extractJS := flag.Bool("js", false, "Extract endpoints from JavaScript hits and queue them")
+	seed := flag.Bool("seed", true, "Seed the scan from robots.txt, sitemaps and .well-known files")
+	backups := flag.Bool("backups", false, "Probe backup and editor-artifact variants of every hit (about 20 requests each)")
+	storeDir := flag.String("store", "", "Directory to keep requests, headers and bodies of kept results")
+	redirectCheck := flag.Bool("redirect-check", false, "Test redirecting paths for open redirects")
+	bypass := flag.Bool("bypass", false, "Retry 401/403 paths with header, path and method bypass tricks")
+	bypassUnsafe := flag.Bool("bypass-methods", false, "Also try state-changing POST and PATCH requests as -bypass methods")
+	profile := flag.Bool("profile", true, "Calibrate case and slash handling to skip duplicate paths")
+	harvest := flag.Bool("harvest", false, "Harvest words from titles, headings, ids and JS names in responses")
+	harvestPass := flag.Bool("harvest-pass", false, "Scan the top harvested words after the wordlist (implies -harvest)")
+	harvestTop := flag.Int("harvest-top", 500, "Harvested words used by -harvest-pass")
+	harvestOut := flag.String("harvest-out", "", "Save harvested words as a wordlist, {host} = target host (implies -harvest)")
+	historyFile := flag.String("history", "", "Hit-history store updated after each scan, e.g. "+DefaultHistoryFile()+" (off by default)")
+	reorder := flag.Bool("reorder", false, "Try wordlist entries with the most past hits first")
+	reorderTech := flag.Bool("reorder-tech", false, "Like -reorder, weighing past hits on the same technology stack higher")
+	autoCalibrate := flag.Bool("ac", false, "Auto-calibrate filters from nonsense requests (status, size, words, lines, redirect)")
 	recursive := flag.Bool("r", false, "Recursive scanning")
 	recursionDepth := flag.Int("depth", 3, "Recursion depth")
+	recursionOrder := flag.String("order", OrderBFS, "Recursion order: bfs (level by level) or dfs (branch by branch)")
+	recurseInclude := flag.String("recurse-include", "", "Only recurse into directories matching these globs (comma-separated)")
+	exclude := flag.String("exclude", "", "Never scan or recurse under paths matching these globs (comma-separated, e.g. static,*/node_modules)")
+	dirBudget := flag.Int("dir-budget", 0, "Max requests queued per recursed directory (0 = unlimited)")
+	recurseStatus := flag.String("recurse-status", "", "Only recurse into directories answering with these status codes (default: any)")
 	outputFile := flag.String("o", "", "Output file")
 	outputFormat := flag.String("of", "text", "Output format")
 	theme := flag.String("theme", "matrix", "Color theme: matrix, rainbow, cyber, blood")
@@ -3650,26 +4517,107 @@ func main() {
 		}
 	}
 
+	categories := parseStringList(strings.ToLower(*category))
+	if err := ValidateWordlistCategories(categories); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var generator *PathGenerator
+	if IsGeneratorSpec(*wordlist) {
+		generator, err = ParseGenerator(*wordlist)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// History holds target-derived paths, so it is only kept when asked for
+	if (*reorder || *reorderTech) && *historyFile == "" {
+		fmt.Println("Error: -reorder needs a -history store to read")
+		os.Exit(1)
+	}
+
+	var rules []MutationRule
+	if *rulesFile != "" {
+		rules, err = LoadMutationRules(*rulesFile)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	config := &Config{
-		StatusCodes:    parseIntList(*statusCodes),
-		FilterStatuses: parseIntList(*filterStatuses),
-		FilterSizes:    parseIntList(*filterSizes),
-		Extensions:     parseStringList(*extensions),
-		CustomHeaders:  customHeaders,
-		Cookie:         *cookie,
-		Method:         *method,
-		RateLimit:      *rateLimit,
-		Delay:          time.Duration(*delay) * time.Millisecond,
-		Recursive:      *recursive,
-		RecursionDepth: *recursionDepth,
-		OutputFile:     *outputFile,
-		OutputFormat:   *outputFormat,
-		Theme:          *theme,
-	}
-
-	// Don't load wordlist here - it will be loaded when user starts a scan
+		StatusCodes:       parseIntList(*statusCodes),
+		FilterStatuses:    parseIntList(*filterStatuses),
+		FilterSizes:       parseIntList(*filterSizes),
+		MatchHeaders:      matchHeaders,
+		FilterHeaders:     filterHeaders,
+		KeepHeaders:       parseStringList(*keepHeaders),
+		Wordlist:          *wordlist,
+		Generator:         generator,
+		Categories:        categories,
+		Extensions:        parseStringList(*extensions),
+		Rules:             rules,
+		CustomHeaders:     customHeaders,
+		Cookie:            *cookie,
+		Method:            *method,
+		RateLimit:         *rateLimit,
+		Delay:             time.Duration(*delay) * time.Millisecond,
+		Recursive:         *recursive,
+		RecursionDepth:    *recursionDepth,
+		RecursionOrder:    validRecursionOrder(*recursionOrder),
+		RecursionInclude:  parseStringList(*recurseInclude),
+		RecursionExclude:  parseStringList(*exclude),
+		RecursionBudget:   *dirBudget,
+		RecursionStatuses: parseIntList(*recurseStatus),
+		Crawl:             *crawl,
+		ExtractJS:         *extractJS,
+		Seed:              *seed,
+		Backups:           *backups,
+		StoreDir:          *storeDir,
+		RedirectCheck:     *redirectCheck,
+		Bypass:            *bypass,
+		BypassUnsafe:      *bypassUnsafe,
+		Profile:           *profile,
+		AutoCalibrate:     *autoCalibrate,
+		AutoExtensions:    *autoExtensions,
+		HistoryFile:       *historyFile,
+		Reorder:           *reorder || *reorderTech,
+		ReorderTech:       *reorderTech,
+		Harvest:           *harvest || *harvestPass || *harvestOut != "",
+		HarvestPass:       *harvestPass,
+		HarvestTop:        *harvestTop,
+		HarvestOut:        *harvestOut,
+		OutputFile:        *outputFile,
+		OutputFormat:      *outputFormat,
+		Theme:             *theme,
+	}
+
 	scanner := NewScanner(*target, *concurrency, *timeout, *verbose, config)
 
+	// Load the wordlist once up front: a bad -wordlist or -category shows
+	// here rather than as a scan that never starts, and with rules the
+	// expanded size is shown before a scan is started. Scans reload it.
+	words, err := LoadScanWordlist(config)
+	if err != nil {
+		fmt.Printf("Error loading wordlist: %v\n", err)
+		os.Exit(1)
+	}
+	if len(rules) > 0 {
+		scanner.expandWordlist(words)
+	}
+
+	if config.StoreDir != "" {
+		store, err := OpenResponseStore(config.StoreDir)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		scanner.store = store
+	}
+
 	// Create TUI
 	tui, err := NewTUI(scanner)
 	if err != nil {
@@ -3687,6 +4635,13 @@ func main() {
 	// After TUI exits, print summary
 	fmt.Println(scanner.AnalyzeResults())
 
+	if scanner.store != nil {
+		if failures, lastErr := scanner.store.Failures(); failures > 0 {
+			fmt.Printf("[!] %d responses could not be stored (last error: %v)\n", failures, lastErr)
+		}
+		fmt.Printf("[OK] Responses stored in %s (index: %s)\n", config.StoreDir, StoreIndexFile)
+	}
+
 	if *outputFile != "" {
 		allResults := append([]*ScanResult{}, scanner.Stats.Direct200s...)
 		allResults = append(allResults, scanner.Stats.Redirects...)
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// ===========================================================================
+// RESPONSE METADATA
+// ===========================================================================
+
+const MaxTitleLength = 200
+
+var (
+	titleRegex       = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	metaCharsetRegex = regexp.MustCompile(`(?is)<meta[^>]+charset\s*=\s*["']?\s*([a-z0-9_:.-]+)`)
+	whitespaceRegex  = regexp.MustCompile(`\s+`)
+)
+
+// HeaderRule matches a response header value against a regex. An empty
+// pattern only requires the header to be present.
+type HeaderRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+func (r HeaderRule) String() string {
+	if r.Pattern == nil {
+		return r.Name
+	}
+	return fmt.Sprintf("%s: %s", r.Name, strings.TrimPrefix(r.Pattern.String(), "(?i)"))
+}
+
+func (r HeaderRule) Matches(header http.Header) bool {
+	values, ok := header[http.CanonicalHeaderKey(r.Name)]
+	if !ok {
+		return false
+	}
+	if r.Pattern == nil {
+		return true
+	}
+	for _, v := range values {
+		if r.Pattern.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerRuleFlag collects repeated -mh/-fh flags ("Name: regex")
+type headerRuleFlag []HeaderRule
+
+func (f *headerRuleFlag) String() string {
+	var parts []string
+	for _, r := range *f {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (f *headerRuleFlag) Set(value string) error {
+	rule, err := ParseHeaderRule(value)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, rule)
+	return nil
+}
+
+func ParseHeaderRule(value string) (HeaderRule, error) {
+	parts := strings.SplitN(value, ":", 2)
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		return HeaderRule{}, fmt.Errorf("header rule %q has no header name", value)
+	}
+	rule := HeaderRule{Name: http.CanonicalHeaderKey(name)}
+	if len(parts) == 2 {
+		if pattern := strings.TrimSpace(parts[1]); pattern != "" {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return HeaderRule{}, fmt.Errorf("header rule %q: %v", value, err)
+			}
+			rule.Pattern = re
+		}
+	}
+	return rule, nil
+}
+
+// captureResponseMetadata fills in the title and the headers we keep on
+// every result so exports and the live view can show what a page is.
+func captureResponseMetadata(result *ScanResult, header http.Header, body []byte, keepHeaders []string) {
+	result.Server = header.Get("Server")
+	result.ContentType = header.Get("Content-Type")
+	result.PoweredBy = header.Get("X-Powered-By")
+
+	for _, name := range keepHeaders {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		if result.Headers == nil {
+			result.Headers = make(map[string]string)
+		}
+		result.Headers[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+	}
+
+	if isHTMLContent(result.ContentType, body) {
+		result.Title = extractTitle(body, result.ContentType)
+	}
+}
+
+func isHTMLContent(contentType string, body []byte) bool {
+	if contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+	}
+	return strings.HasPrefix(http.DetectContentType(body), "text/html")
+}
+
+// extractTitle pulls <title> out of an HTML body, decoding it with the
+// charset from the Content-Type header or a <meta charset> tag.
+func extractTitle(body []byte, contentType string) string {
+	match := titleRegex.FindSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	raw := match[1]
+
+	title := decodeCharset(raw, detectCharset(body, contentType))
+	title = html.UnescapeString(title)
+	title = strings.TrimSpace(whitespaceRegex.ReplaceAllString(title, " "))
+
+	if len(title) > MaxTitleLength {
+		title = strings.ToValidUTF8(truncateString(title, MaxTitleLength), "")
+	}
+	return title
+}
+
+func detectCharset(body []byte, contentType string) string {
+	if contentType != "" {
+		if _, params, err := mime.ParseMediaType(contentType); err == nil && params["charset"] != "" {
+			return params["charset"]
+		}
+	}
+	// The meta tag has to appear in the first 1024 bytes per the HTML spec
+	head := body
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if m := metaCharsetRegex.FindSubmatch(head); m != nil {
+		return string(m[1])
+	}
+	if utf8.Valid(body) {
+		return "utf-8"
+	}
+	return "windows-1252"
+}
+
+func decodeCharset(data []byte, charset string) string {
+	enc, err := htmlindex.Get(charset)
+	if err != nil || enc == nil {
+		return strings.ToValidUTF8(string(data), "")
+	}
+	decoded, err := io.ReadAll(enc.NewDecoder().Reader(bytes.NewReader(data)))
+	if err != nil {
+		return strings.ToValidUTF8(string(data), "")
+	}
+	return string(decoded)
+}
+
+// shortContentType turns "text/html; charset=utf-8" into "text/html"
+func shortContentType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+}
+
+func formatKeptHeaders(headers map[string]string) string {
+	if len(headers) == 0 {
+		return ""
+	}
+	var parts []string
+	for name, value := range headers {
+		parts = append(parts, name+"="+value)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
+
+// resultDetails is the short "what is this page" suffix for the live view.
+// Paths that didn't come from the wordlist are tagged with their source.
+func resultDetails(result *ScanResult) string {
+	var parts []string
+	if tag := clusterTag(result); tag != "" {
+		parts = append(parts, tag)
+	}
+	if tag := findingsTag(result); tag != "" {
+		parts = append(parts, tag)
+	}
+	if tag := slowTag(result); tag != "" {
+		parts = append(parts, tag)
+	}
+	if tag := bypassTag(result); tag != "" {
+		parts = append(parts, tag)
+	}
+	if result.OpenRedirect != nil {
+		parts = append(parts, "[!OPEN REDIRECT "+result.OpenRedirect.Parameter+"]")
+	}
+	if result.Kind == KindDirectory && result.Listing == "" {
+		parts = append(parts, "[dir]")
+	}
+	if result.Listing != "" {
+		parts = append(parts, fmt.Sprintf("[LISTING %s, %d entries]", result.Listing, len(result.ListingEntries)))
+	}
+	if result.VariantOf != "" {
+		parts = append(parts, "[backup of "+result.VariantOf+"]")
+	} else if result.Source != "" && result.Source != SourceWordlist {
+		parts = append(parts, "["+result.Source+"]")
+	}
+	if result.Title != "" {
+		parts = append(parts, fmt.Sprintf("%q", result.Title))
+	}
+	if ct := shortContentType(result.ContentType); ct != "" && ct != "text/html" {
+		parts = append(parts, ct)
+	}
+	if result.Server != "" {
+		parts = append(parts, "("+result.Server+")")
+	}
+	return strings.Join(parts, " ")
+}
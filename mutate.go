@@ -0,0 +1,343 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ===========================================================================
+// WORDLIST MUTATION RULES (-rules)
+// ===========================================================================
+
+// A rules file has one rule per line. A rule is a chain of operations
+// applied left to right; operations that produce several words (years,
+// nums) fan out, so "c years 2023-2025" yields Admin2023..Admin2025.
+// Blank lines and lines starting with # are ignored.
+//
+//	:             keep the word as is
+//	l u c t       lowercase, uppercase, capitalize, toggle case
+//	$X ^X         append / prepend X (hashcat style, X can be several chars)
+//	suffix X      same as $X
+//	prefix X      same as ^X
+//	sep X         rejoin the word's parts with X (admin_panel -> admin-panel)
+//	join          rejoin without a separator (adminPanel -> adminpanel)
+//	camel pascal  adminPanel / AdminPanel
+//	years A-B     append each year from A to B
+//	nums A-B      append each number from A to B
+//	plural        admin -> admins, policy -> policies
+//	singular      users -> user, entries -> entry
+//
+// Operations only touch the last path segment, and leave a file extension
+// and a dotfile's leading dot alone: "api/userList.php" with "sep _" becomes
+// "api/user_list.php", ".htaccess" with "u" becomes ".HTACCESS".
+
+// mutationOp turns one word stem into zero or more stems
+type mutationOp func(string) []string
+
+// MutationRule is one parsed line of a rules file
+type MutationRule struct {
+	Text string
+	ops  []mutationOp
+}
+
+// Apply runs the rule on a wordlist entry
+func (r MutationRule) Apply(word string) []string {
+	dir, segment := "", word
+	if i := strings.LastIndex(word, "/"); i >= 0 {
+		dir, segment = word[:i+1], word[i+1:]
+	}
+	// A dotfile's leading dot stays put, like the extension
+	if strings.HasPrefix(segment, ".") {
+		dir, segment = dir+".", segment[1:]
+	}
+	stem, ext := segment, ""
+	if e := path.Ext(segment); e != "" && e != segment {
+		stem, ext = strings.TrimSuffix(segment, e), e
+	}
+	if stem == "" {
+		return nil
+	}
+
+	stems := []string{stem}
+	for _, op := range r.ops {
+		var next []string
+		for _, s := range stems {
+			next = append(next, op(s)...)
+		}
+		stems = next
+	}
+
+	words := make([]string, 0, len(stems))
+	for _, s := range stems {
+		if s != "" {
+			words = append(words, dir+s+ext)
+		}
+	}
+	return words
+}
+
+// LoadMutationRules reads and parses a rules file
+func LoadMutationRules(filename string) ([]MutationRule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []MutationRule
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := ParseMutationRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", filename, n+1, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ParseMutationRule parses one rule line
+func ParseMutationRule(line string) (MutationRule, error) {
+	rule := MutationRule{Text: line}
+	tokens := strings.Fields(line)
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		arg := func() (string, error) {
+			if i+1 >= len(tokens) {
+				return "", fmt.Errorf("%s needs an argument", token)
+			}
+			i++
+			return tokens[i], nil
+		}
+
+		var op mutationOp
+		switch {
+		case token == ":":
+			op = func(s string) []string { return []string{s} }
+		case token == "l":
+			op = single(strings.ToLower)
+		case token == "u":
+			op = single(strings.ToUpper)
+		case token == "c":
+			op = single(capitalize)
+		case token == "t":
+			op = single(swapCase)
+		case token == "join":
+			op = single(func(s string) string { return strings.ToLower(strings.Join(wordParts(s), "")) })
+		case token == "camel":
+			op = single(func(s string) string { return camelCase(wordParts(s), false) })
+		case token == "pascal":
+			op = single(func(s string) string { return camelCase(wordParts(s), true) })
+		case token == "plural":
+			op = single(pluralize)
+		case token == "singular":
+			op = single(singularize)
+		case len(token) > 1 && (token[0] == '$' || token[0] == '^'):
+			op = affix(token[1:], token[0] == '^')
+		case token == "suffix" || token == "prefix":
+			value, err := arg()
+			if err != nil {
+				return rule, err
+			}
+			op = affix(value, token == "prefix")
+		case token == "sep":
+			separator, err := arg()
+			if err != nil {
+				return rule, err
+			}
+			op = single(func(s string) string { return strings.ToLower(strings.Join(wordParts(s), separator)) })
+		case token == "years" || token == "nums":
+			value, err := arg()
+			if err != nil {
+				return rule, err
+			}
+			from, to, err := parseRange(value)
+			if err != nil {
+				return rule, fmt.Errorf("%s: %v", token, err)
+			}
+			op = func(s string) []string {
+				words := make([]string, 0, to-from+1)
+				for n := from; n <= to; n++ {
+					words = append(words, s+strconv.Itoa(n))
+				}
+				return words
+			}
+		default:
+			return rule, fmt.Errorf("unknown operation %q", token)
+		}
+		rule.ops = append(rule.ops, op)
+	}
+	return rule, nil
+}
+
+func single(f func(string) string) mutationOp {
+	return func(s string) []string { return []string{f(s)} }
+}
+
+func affix(value string, prefix bool) mutationOp {
+	if prefix {
+		return single(func(s string) string { return value + s })
+	}
+	return single(func(s string) string { return s + value })
+}
+
+// parseRange parses "A-B" or a single number. Ranges are capped at 10000
+// entries, since each one is a request per word.
+func parseRange(value string) (int, int, error) {
+	low, high, isRange := strings.Cut(value, "-")
+	from, err := strconv.Atoi(low)
+	if err != nil {
+		return 0, 0, fmt.Errorf("bad range %q", value)
+	}
+	to := from
+	if isRange {
+		if to, err = strconv.Atoi(high); err != nil {
+			return 0, 0, fmt.Errorf("bad range %q", value)
+		}
+	}
+	if to < from || to-from >= 10000 {
+		return 0, 0, fmt.Errorf("bad range %q", value)
+	}
+	return from, to, nil
+}
+
+// wordParts splits a name on separators and case changes:
+// "adminPanel", "admin_panel", "Admin-Panel" all give [admin panel].
+// Digit runs are parts of their own ("admin2024" -> [admin 2024]).
+func wordParts(s string) []string {
+	var parts []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			parts = append(parts, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ':
+			flush()
+			continue
+		case i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1]))):
+			// adminPanel, and the P in "APIPanel"
+			flush()
+		case i > 0 && unicode.IsDigit(r) != unicode.IsDigit(runes[i-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+	return parts
+}
+
+// capitalize uppercases the first character (not byte, so "администратор"
+// stays valid UTF-8) and lowercases the rest
+func capitalize(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(first)) + strings.ToLower(s[size:])
+}
+
+func camelCase(parts []string, pascal bool) string {
+	var sb strings.Builder
+	for i, part := range parts {
+		if i == 0 && !pascal {
+			sb.WriteString(part)
+		} else {
+			sb.WriteString(capitalize(part))
+		}
+	}
+	return sb.String()
+}
+
+// pluralize handles the regular English plurals common in route names.
+// Words that already look plural are returned unchanged.
+func pluralize(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "ss") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
+		strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "s"):
+		return s
+	case len(lower) > 1 && strings.HasSuffix(lower, "y") && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
+
+// singularize reverses pluralize
+func singularize(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(lower, "sses") || strings.HasSuffix(lower, "xes") || strings.HasSuffix(lower, "zes") ||
+		strings.HasSuffix(lower, "ches") || strings.HasSuffix(lower, "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(lower, "ss") || strings.HasSuffix(lower, "us"):
+		return s
+	case strings.HasSuffix(lower, "s") && len(lower) > 1:
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// MutateWordlist returns the wordlist followed by every rule's output, rule
+// by rule, without duplicates
+func MutateWordlist(words []string, rules []MutationRule) []string {
+	if len(rules) == 0 {
+		return words
+	}
+
+	seen := make(map[string]bool, len(words))
+	expanded := make([]string, 0, len(words)*(len(rules)+1))
+	add := func(w string) {
+		if !seen[w] {
+			seen[w] = true
+			expanded = append(expanded, w)
+		}
+	}
+
+	for _, w := range words {
+		add(w)
+	}
+	for _, rule := range rules {
+		for _, w := range words {
+			for _, mutated := range rule.Apply(w) {
+				add(mutated)
+			}
+		}
+	}
+	return expanded
+}
+
+// expandWordlist applies -rules to a wordlist and records the counts shown
+// in the SCAN CONFIG box
+func (s *Scanner) expandWordlist(words []string) []string {
+	expanded := MutateWordlist(words, s.Config.Rules)
+	s.wordlistWords = len(words)
+	s.wordlistExpanded = len(expanded)
+	return expanded
+}
+
+// wordlistSummary is the wordlist line of the SCAN CONFIG box
+func (s *Scanner) wordlistSummary(name string) string {
+	if len(s.Config.Rules) > 0 && s.wordlistWords > 0 {
+		return fmt.Sprintf("Wordlist: %s (%d words, %d with %d rules)", name, s.wordlistWords, s.wordlistExpanded, len(s.Config.Rules))
+	}
+	return "Wordlist: " + name
+}
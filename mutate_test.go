@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"admin", "Admin"},
+		{"ADMIN", "Admin"},
+		{"a", "A"},
+		{"администратор", "Администратор"},
+		{"管理员", "管理员"},
+		{"éditeur", "Éditeur"},
+	}
+	for _, tt := range tests {
+		got := capitalize(tt.in)
+		if got != tt.want {
+			t.Errorf("capitalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("capitalize(%q) = %q is not valid UTF-8", tt.in, got)
+		}
+	}
+}
+
+func TestParseMutationRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		word string
+		want []string
+	}{
+		{":", "admin", []string{"admin"}},
+		{"u", "admin", []string{"ADMIN"}},
+		{"c", "admin_panel", []string{"Admin_panel"}},
+		{"c", "администратор", []string{"Администратор"}},
+		{"camel", "admin_panel", []string{"adminPanel"}},
+		{"pascal", "admin-panel", []string{"AdminPanel"}},
+		{"pascal", "панель_управления", []string{"ПанельУправления"}},
+		{"sep _", "adminPanel", []string{"admin_panel"}},
+		{"join", "Admin-Panel", []string{"adminpanel"}},
+		{"$_old", "backup", []string{"backup_old"}},
+		{"^old_", "backup", []string{"old_backup"}},
+		{"suffix -dev", "api", []string{"api-dev"}},
+		{"prefix _", "config", []string{"_config"}},
+		{"years 2023-2025", "report", []string{"report2023", "report2024", "report2025"}},
+		{"c nums 1-2", "user", []string{"User1", "User2"}},
+		{"plural", "policy", []string{"policies"}},
+		{"plural", "box", []string{"boxes"}},
+		{"singular", "entries", []string{"entry"}},
+		{"sep _", "api/userList.php", []string{"api/user_list.php"}},
+		{"u", ".htaccess", []string{".HTACCESS"}},
+	}
+	for _, tt := range tests {
+		rule, err := ParseMutationRule(tt.rule)
+		if err != nil {
+			t.Errorf("ParseMutationRule(%q): %v", tt.rule, err)
+			continue
+		}
+		if got := rule.Apply(tt.word); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("rule %q on %q = %q, want %q", tt.rule, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestParseMutationRuleErrors(t *testing.T) {
+	for _, rule := range []string{
+		"bogus",
+		"suffix",
+		"sep",
+		"years",
+		"years 2025-2020",
+		"nums x",
+		"nums 1-20000",
+	} {
+		if _, err := ParseMutationRule(rule); err == nil {
+			t.Errorf("ParseMutationRule(%q) succeeded, want an error", rule)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// OPEN REDIRECT VERIFICATION
+// ===========================================================================
+
+// RedirectCanaryHost is the attacker-controlled host injected into redirect
+// parameters. It's under the reserved .invalid TLD so it can never resolve -
+// redirects are never followed, only the Location header is checked.
+const RedirectCanaryHost = "pathfinder-canary.invalid"
+
+// Parameters that commonly carry a post-login or post-action redirect target
+var redirectParams = []string{
+	"redirect", "redirect_url", "redirect_uri", "redirectUrl", "redir", "url",
+	"next", "return", "returnUrl", "return_to", "returnTo", "goto", "dest",
+	"destination", "continue", "target", "to", "out", "forward", "callback",
+}
+
+// Canary forms that get past the usual "starts with /" and scheme checks
+var redirectPayloads = []string{
+	"https://" + RedirectCanaryHost + "/",
+	"//" + RedirectCanaryHost + "/",
+	"/\\" + RedirectCanaryHost + "/",
+}
+
+// OpenRedirect is a confirmed open redirect: requesting PayloadURL answers
+// with a Location on the canary host.
+type OpenRedirect struct {
+	Path       string // Result the check started from
+	PayloadURL string // Exact request that redirects off-site
+	Parameter  string // Injected parameter, or "(path)" for path injection
+	Location   string // Location header the server sent back
+	Status     int
+}
+
+type redirectAttempt struct {
+	URL       string
+	Parameter string
+	Params    []string // For combined attempts, the parameters set at once
+}
+
+func isRedirectParam(name string) bool {
+	for _, p := range redirectParams {
+		if strings.EqualFold(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRedirectAttempts lists the requests to try for a redirecting result:
+// existing redirect parameters along the chain first, then all common
+// parameter names at once on the original URL, then path injection.
+func buildRedirectAttempts(result *ScanResult) []redirectAttempt {
+	var attempts []redirectAttempt
+	seen := make(map[string]bool)
+	add := func(a redirectAttempt) {
+		if !seen[a.URL] {
+			seen[a.URL] = true
+			attempts = append(attempts, a)
+		}
+	}
+
+	// /admin -> /login?next=/admin: the parameter is already there
+	hops := []string{result.OriginalURL, result.FinalURL}
+	for _, step := range result.RedirectChain {
+		hops = append(hops, step.URL)
+	}
+	for _, hop := range hops {
+		u, err := url.Parse(hop)
+		if err != nil {
+			continue
+		}
+		query := u.Query()
+		for name := range query {
+			if !isRedirectParam(name) {
+				continue
+			}
+			for _, payload := range redirectPayloads {
+				injected := *u
+				q := u.Query()
+				q.Set(name, payload)
+				injected.RawQuery = q.Encode()
+				add(redirectAttempt{URL: injected.String(), Parameter: name})
+			}
+		}
+	}
+
+	original, err := url.Parse(result.OriginalURL)
+	if err != nil {
+		return attempts
+	}
+
+	// Every common parameter at once - narrowed down only if it works
+	for _, payload := range redirectPayloads {
+		injected := *original
+		q := original.Query()
+		for _, name := range redirectParams {
+			if q.Get(name) == "" {
+				q.Set(name, payload)
+			}
+		}
+		injected.RawQuery = q.Encode()
+		add(redirectAttempt{URL: injected.String(), Params: redirectParams})
+	}
+
+	// Path-based: /path//canary/ and /path/%2F%2Fcanary
+	base := strings.TrimSuffix(original.Scheme+"://"+original.Host+original.EscapedPath(), "/")
+	add(redirectAttempt{URL: base + "//" + RedirectCanaryHost + "/%2F..", Parameter: "(path)"})
+	add(redirectAttempt{URL: base + "/%2F%2F" + RedirectCanaryHost, Parameter: "(path)"})
+
+	return attempts
+}
+
+// redirectsToCanary reports whether a Location header sends the browser to
+// the canary host. Backslashes are treated like slashes, as browsers do.
+func redirectsToCanary(location, requestURL string) bool {
+	if location == "" {
+		return false
+	}
+	base, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	target, err := base.Parse(strings.ReplaceAll(strings.TrimSpace(location), "\\", "/"))
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(target.Hostname(), RedirectCanaryHost)
+}
+
+// tryRedirect sends one attempt without following redirects
+func (s *Scanner) tryRedirect(attemptURL string) (string, int, bool) {
+	result, err := s.fetchWithOptions(attemptURL, requestOptions{Method: "GET", NoFollow: true})
+	if err != nil || result.FinalStatus < 300 || result.FinalStatus >= 400 {
+		return "", 0, false
+	}
+	location := result.header.Get("Location")
+	return location, result.FinalStatus, redirectsToCanary(location, attemptURL)
+}
+
+// checkOpenRedirect injects the canary into a redirecting result and
+// records the first payload that sends the Location off-site.
+func (s *Scanner) checkOpenRedirect(result *ScanResult) {
+	if len(result.RedirectChain) == 0 {
+		return
+	}
+
+	for _, attempt := range buildRedirectAttempts(result) {
+		// Many paths redirect through the same login?next= hop - try each
+		// injected URL once per scan
+		s.pathMutex.Lock()
+		tried := s.redirectTried[attempt.URL]
+		s.redirectTried[attempt.URL] = true
+		s.pathMutex.Unlock()
+		if tried {
+			continue
+		}
+
+		location, status, ok := s.tryRedirect(attempt.URL)
+		if !ok {
+			continue
+		}
+
+		finding := &OpenRedirect{
+			Path:       result.OriginalPath,
+			PayloadURL: attempt.URL,
+			Parameter:  attempt.Parameter,
+			Location:   location,
+			Status:     status,
+		}
+
+		// A combined attempt worked - find the parameter responsible so the
+		// report has the minimal reproduction
+		if len(attempt.Params) > 0 {
+			finding.Parameter = "(combined)"
+			if single := s.narrowRedirectParam(result.OriginalURL, attempt.URL); single != nil {
+				finding = single
+				finding.Path = result.OriginalPath
+			}
+		}
+
+		result.OpenRedirect = finding
+		s.Stats.mu.Lock()
+		s.Stats.OpenRedirects = append(s.Stats.OpenRedirects, result)
+		s.Stats.mu.Unlock()
+		return
+	}
+}
+
+// narrowRedirectParam retries a successful combined attempt one parameter at
+// a time and returns the first single-parameter payload that still works.
+func (s *Scanner) narrowRedirectParam(originalURL, combinedURL string) *OpenRedirect {
+	original, err := url.Parse(originalURL)
+	if err != nil {
+		return nil
+	}
+	combined, err := url.Parse(combinedURL)
+	if err != nil {
+		return nil
+	}
+	payloads := combined.Query()
+
+	for _, name := range redirectParams {
+		payload := payloads.Get(name)
+		if payload == "" || original.Query().Get(name) != "" {
+			continue
+		}
+		single := *original
+		q := original.Query()
+		q.Set(name, payload)
+		single.RawQuery = q.Encode()
+
+		if location, status, ok := s.tryRedirect(single.String()); ok {
+			return &OpenRedirect{
+				PayloadURL: single.String(),
+				Parameter:  name,
+				Location:   location,
+				Status:     status,
+			}
+		}
+	}
+	return nil
+}
+
+// SortedOpenRedirects returns results with a confirmed open redirect, by path
+func (s *Scanner) SortedOpenRedirects() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.OpenRedirects))
+	copy(sorted, s.Stats.OpenRedirects)
+	s.Stats.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OriginalPath < sorted[j].OriginalPath
+	})
+	return sorted
+}
+
+// openRedirectPayload is the proving request for exports ("" if none)
+func openRedirectPayload(result *ScanResult) string {
+	if result.OpenRedirect == nil {
+		return ""
+	}
+	return result.OpenRedirect.PayloadURL
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedirectsToCanary(t *testing.T) {
+	const requestURL = "https://example.com/login?next=x"
+	tests := map[string]bool{
+		"https://" + RedirectCanaryHost + "/":        true,
+		"//" + RedirectCanaryHost + "/path":          true,
+		"/\\" + RedirectCanaryHost + "/":             true,
+		"HTTPS://PATHFINDER-CANARY.INVALID/":         true,
+		"/dashboard":                                 false,
+		"https://example.com/" + RedirectCanaryHost:  false,
+		"https://" + RedirectCanaryHost + ".evil.io": false,
+		"": false,
+	}
+	for location, want := range tests {
+		if got := redirectsToCanary(location, requestURL); got != want {
+			t.Errorf("redirectsToCanary(%q) = %v, want %v", location, got, want)
+		}
+	}
+}
+
+func TestIsRedirectParam(t *testing.T) {
+	tests := map[string]bool{
+		"next":        true,
+		"ReturnURL":   true,
+		"redirect_to": false,
+		"page":        false,
+	}
+	for name, want := range tests {
+		if got := isRedirectParam(name); got != want {
+			t.Errorf("isRedirectParam(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestBuildRedirectAttempts(t *testing.T) {
+	result := &ScanResult{
+		OriginalURL: "https://example.com/admin",
+		FinalURL:    "https://example.com/login?next=%2Fadmin",
+	}
+	attempts := buildRedirectAttempts(result)
+
+	// next= along the chain first, one attempt per payload
+	for i := range redirectPayloads {
+		if i >= len(attempts) || attempts[i].Parameter != "next" {
+			t.Fatalf("attempt %d: want the existing next parameter first, got %+v", i, attempts)
+		}
+		u, err := url.Parse(attempts[i].URL)
+		if err != nil || u.Path != "/login" || u.Query().Get("next") != redirectPayloads[i] {
+			t.Errorf("attempt %d: URL %q doesn't inject payload %q into next", i, attempts[i].URL, redirectPayloads[i])
+		}
+	}
+
+	// Then every common parameter at once on the original URL
+	combined := attempts[len(redirectPayloads)]
+	if len(combined.Params) != len(redirectParams) {
+		t.Errorf("combined attempt sets %d parameters, want %d", len(combined.Params), len(redirectParams))
+	}
+	if u, err := url.Parse(combined.URL); err != nil || u.Path != "/admin" || u.Query().Get("redirect") != redirectPayloads[0] {
+		t.Errorf("combined attempt URL %q", combined.URL)
+	}
+
+	// Path injection last
+	var paths int
+	for _, a := range attempts {
+		if a.Parameter == "(path)" {
+			paths++
+			if !strings.HasPrefix(a.URL, "https://example.com/admin/") || !strings.Contains(a.URL, RedirectCanaryHost) {
+				t.Errorf("path attempt URL %q", a.URL)
+			}
+		}
+	}
+	if paths != 2 {
+		t.Errorf("got %d path attempts, want 2", paths)
+	}
+
+	want := 2*len(redirectPayloads) + 2
+	if len(attempts) != want {
+		t.Errorf("got %d attempts, want %d", len(attempts), want)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ===========================================================================
+// SERVER NORMALIZATION PROFILE
+// ===========================================================================
+
+// Paths that exist on most servers, used to calibrate before the wordlist runs
+var profileCandidates = []string{"robots.txt", "favicon.ico", "index.html", "sitemap.xml"}
+
+// ProfileTrait is one calibrated server behavior; unknown until a probe
+// gives a clear answer.
+type ProfileTrait int
+
+const (
+	TraitUnknown ProfileTrait = iota
+	TraitYes
+	TraitNo
+)
+
+func (t ProfileTrait) String() string {
+	switch t {
+	case TraitYes:
+		return "yes"
+	case TraitNo:
+		return "no"
+	}
+	return "unknown"
+}
+
+// ServerProfile records how the target maps request paths to resources.
+// Once a trait is known, paths that only differ in that way share a visited
+// key and are requested once.
+type ServerProfile struct {
+	CaseInsensitive ProfileTrait // /Admin serves /admin
+	MergesSlashes   ProfileTrait // //admin serves /admin
+	DecodesSlash    ProfileTrait // /a%2fb serves /a/b
+	Evidence        []string     // Hits each trait was calibrated on
+	Deduped         int          // Paths skipped as variants of one already visited
+}
+
+// Complete reports whether every trait has been decided
+func (p ServerProfile) Complete() bool {
+	return p.CaseInsensitive != TraitUnknown && p.MergesSlashes != TraitUnknown && p.DecodesSlash != TraitUnknown
+}
+
+// Normalize maps a visited-set key to the form the server would resolve it
+// to. The query string is left alone - only the path follows the profile.
+func (p ServerProfile) Normalize(key string) string {
+	path, query, hasQuery := strings.Cut(key, "?")
+
+	if p.DecodesSlash == TraitYes {
+		path = strings.ReplaceAll(path, "%2f", "/")
+		path = strings.ReplaceAll(path, "%2F", "/")
+	}
+	if p.MergesSlashes == TraitYes {
+		for strings.Contains(path, "//") {
+			path = strings.ReplaceAll(path, "//", "/")
+		}
+		path = strings.TrimPrefix(path, "/")
+	}
+	if p.CaseInsensitive == TraitYes {
+		path = strings.ToLower(path)
+	}
+
+	if hasQuery {
+		return path + "?" + query
+	}
+	return path
+}
+
+// Summary is the one-line description used in the dashboard and report
+func (p ServerProfile) Summary() string {
+	return fmt.Sprintf("case-insensitive: %s, merges //: %s, decodes %%2f: %s",
+		p.CaseInsensitive, p.MergesSlashes, p.DecodesSlash)
+}
+
+// calibrateProfile probes the usual always-present files before the wordlist
+// is queued, so case and slash variants in the wordlist itself collapse.
+// Whatever stays unknown is filled in from real hits as they come in.
+func (s *Scanner) calibrateProfile() {
+	for _, candidate := range profileCandidates {
+		result, err := s.FetchWithRedirectTracking(s.BaseURL + "/" + candidate)
+		if err != nil || !result.IsDirect200 || s.IsWildcardResponse(result) {
+			continue
+		}
+		s.profileFromHit(result)
+
+		s.pathMutex.Lock()
+		complete := s.profile.Complete()
+		s.pathMutex.Unlock()
+		if complete {
+			return
+		}
+	}
+}
+
+// profileFromHit uses a direct 200 to decide any traits still unknown. Only
+// one hit is probed at a time; other workers just carry on.
+func (s *Scanner) profileFromHit(result *ScanResult) {
+	if !result.IsDirect200 {
+		return
+	}
+
+	s.pathMutex.Lock()
+	if s.profiling || s.profile.Complete() {
+		s.pathMutex.Unlock()
+		return
+	}
+	s.profiling = true
+	profile := s.profile
+	s.pathMutex.Unlock()
+
+	defer func() {
+		s.pathMutex.Lock()
+		s.profiling = false
+		s.pathMutex.Unlock()
+	}()
+
+	u, err := url.Parse(result.OriginalURL)
+	if err != nil {
+		return
+	}
+	p := u.EscapedPath()
+	if strings.Trim(p, "/") == "" {
+		return
+	}
+
+	probe := func(mutated string) ProfileTrait {
+		variant := *u
+		variant.RawPath = mutated
+		variant.Path, _ = url.PathUnescape(mutated)
+		v, err := s.FetchWithRedirectTracking(variant.String())
+		if err != nil || s.IsWildcardResponse(v) {
+			return TraitUnknown
+		}
+		if v.FinalStatus == result.FinalStatus && (v.ContentHash == result.ContentHash ||
+			NormalizedHash(v.body, v.OriginalPath) == NormalizedHash(result.body, result.OriginalPath)) {
+			return TraitYes
+		}
+		return TraitNo
+	}
+
+	decided := false
+	if profile.CaseInsensitive == TraitUnknown {
+		if swapped := swapCase(p); swapped != p {
+			profile.CaseInsensitive = probe(swapped)
+			decided = decided || profile.CaseInsensitive != TraitUnknown
+		}
+	}
+	if profile.MergesSlashes == TraitUnknown {
+		profile.MergesSlashes = probe("/" + p)
+		decided = decided || profile.MergesSlashes != TraitUnknown
+	}
+	if profile.DecodesSlash == TraitUnknown {
+		if i := strings.LastIndex(p, "/"); i > 0 {
+			profile.DecodesSlash = probe(p[:i] + "%2f" + p[i+1:])
+			decided = decided || profile.DecodesSlash != TraitUnknown
+		}
+	}
+	if !decided {
+		return
+	}
+
+	s.pathMutex.Lock()
+	if s.profile.CaseInsensitive == TraitUnknown {
+		s.profile.CaseInsensitive = profile.CaseInsensitive
+	}
+	if s.profile.MergesSlashes == TraitUnknown {
+		s.profile.MergesSlashes = profile.MergesSlashes
+	}
+	if s.profile.DecodesSlash == TraitUnknown {
+		s.profile.DecodesSlash = profile.DecodesSlash
+	}
+	s.profile.Evidence = append(s.profile.Evidence, result.OriginalPath)
+
+	// Paths visited before calibration get their normalized form too, so a
+	// later variant of one of them is still caught
+	for key := range s.visitedPaths {
+		s.visitedPaths[s.profile.Normalize(key)] = true
+	}
+	s.pathMutex.Unlock()
+}
+
+// swapCase flips the case of every letter in a path
+func swapCase(p string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, p)
+}
+
+// ServerProfile returns a snapshot of the calibrated profile
+func (s *Scanner) ServerProfile() ServerProfile {
+	s.pathMutex.Lock()
+	defer s.pathMutex.Unlock()
+
+	profile := s.profile
+	profile.Evidence = append([]string(nil), s.profile.Evidence...)
+	return profile
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ===========================================================================
+// SCAN QUEUE
+// ===========================================================================
+
+// Where a path came from, shown on results and in exports
+const (
+	SourceWordlist = "wordlist"
+	SourceCrawl    = "crawl"
+)
+
+type QueuedPath struct {
+	Path   string
+	Source string
+	Depth  int // Recursion level: 0 for the wordlist and discovered paths
+}
+
+// Orders the frontier can hand out work in
+const (
+	OrderBFS = "bfs" // Shallowest level first: a directory's siblings before its children
+	OrderDFS = "dfs" // Deepest level first: finish one branch before the next
+)
+
+// DepthProgress is how far the scan has got through one recursion level
+type DepthProgress struct {
+	Depth  int
+	Done   int
+	Queued int // Everything ever queued at this depth, including Done
+	Dirs   int // Directories at this depth that were expanded
+	Over   int // Children left out by the per-directory budget
+}
+
+// scanQueue is the frontier feeding ScanAll's workers. Paths can be pushed
+// at any time while a scan runs, including by workers expanding a
+// directory, and nothing is ever dropped except by Clear. Items are kept in
+// one FIFO per depth and handed out in the configured order. Pop only
+// reports the queue as drained once it is empty and no worker is still
+// processing an item (which might push more).
+type scanQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	order    string
+	levels   map[int][]QueuedPath
+	progress map[int]*DepthProgress
+	size     int
+	inflight int
+}
+
+func newScanQueue(order string) *scanQueue {
+	q := &scanQueue{
+		order:    order,
+		levels:   make(map[int][]QueuedPath),
+		progress: make(map[int]*DepthProgress),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// level returns the progress counters of a depth, creating them if needed.
+// Caller holds q.mu.
+func (q *scanQueue) level(depth int) *DepthProgress {
+	p, ok := q.progress[depth]
+	if !ok {
+		p = &DepthProgress{Depth: depth}
+		q.progress[depth] = p
+	}
+	return p
+}
+
+func (q *scanQueue) Push(items ...QueuedPath) {
+	q.mu.Lock()
+	for _, item := range items {
+		q.levels[item.Depth] = append(q.levels[item.Depth], item)
+		q.level(item.Depth).Queued++
+	}
+	q.size += len(items)
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// next picks the depth to pop from. Caller holds q.mu and q.size > 0.
+func (q *scanQueue) next() int {
+	picked, found := 0, false
+	for depth, items := range q.levels {
+		if len(items) == 0 {
+			continue
+		}
+		if !found || (q.order == OrderDFS && depth > picked) || (q.order != OrderDFS && depth < picked) {
+			picked, found = depth, true
+		}
+	}
+	return picked
+}
+
+// Pop blocks until an item is available or the queue is drained. Every
+// successful Pop must be paired with a call to Done.
+func (q *scanQueue) Pop() (QueuedPath, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == 0 {
+		if q.inflight == 0 {
+			return QueuedPath{}, false
+		}
+		q.cond.Wait()
+	}
+
+	depth := q.next()
+	items := q.levels[depth]
+	item := items[0]
+	items[0] = QueuedPath{}
+	if len(items) == 1 {
+		delete(q.levels, depth)
+	} else {
+		q.levels[depth] = items[1:]
+	}
+	q.size--
+	q.inflight++
+	// A producer in WaitBelow may have room now
+	q.cond.Broadcast()
+	return item, true
+}
+
+// Done marks a popped item as finished
+func (q *scanQueue) Done(item QueuedPath) {
+	q.mu.Lock()
+	q.inflight--
+	q.level(item.Depth).Done++
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// Hold keeps the queue from reporting itself drained until Release, for a
+// producer that pushes over time rather than from a worker
+func (q *scanQueue) Hold() {
+	q.mu.Lock()
+	q.inflight++
+	q.mu.Unlock()
+}
+
+// Release ends a Hold
+func (q *scanQueue) Release() {
+	q.mu.Lock()
+	q.inflight--
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// WaitBelow blocks until fewer than n items are waiting
+func (q *scanQueue) WaitBelow(n int) {
+	q.mu.Lock()
+	for q.size >= n {
+		q.cond.Wait()
+	}
+	q.mu.Unlock()
+}
+
+// AddDir counts an expanded directory at a depth
+func (q *scanQueue) AddDir(depth int) {
+	q.mu.Lock()
+	q.level(depth).Dirs++
+	q.mu.Unlock()
+}
+
+// SetOrder switches between BFS and DFS for the paths still queued
+func (q *scanQueue) SetOrder(order string) {
+	q.mu.Lock()
+	q.order = order
+	q.mu.Unlock()
+}
+
+// AddOverBudget counts a child left out by the per-directory budget
+func (q *scanQueue) AddOverBudget(depth int) {
+	q.mu.Lock()
+	q.level(depth).Over++
+	q.mu.Unlock()
+}
+
+func (q *scanQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Clear drops everything still waiting and returns how many items were
+// dropped. Dropped items count as done so per-depth progress completes.
+func (q *scanQueue) Clear() int {
+	q.mu.Lock()
+	dropped := q.size
+	for depth, items := range q.levels {
+		q.level(depth).Done += len(items)
+	}
+	q.levels = make(map[int][]QueuedPath)
+	q.size = 0
+	q.mu.Unlock()
+	q.cond.Broadcast()
+	return dropped
+}
+
+// Progress returns per-depth counters, shallowest first
+func (q *scanQueue) Progress() []DepthProgress {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	progress := make([]DepthProgress, 0, len(q.progress))
+	for _, p := range q.progress {
+		progress = append(progress, *p)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		return progress[i].Depth < progress[j].Depth
+	})
+	return progress
+}
+
+// visitKey normalizes a path for the visited set ("/admin" and "admin" are
+// the same request since ScanPath strips the leading slash). A trailing
+// slash is dropped too: a crawled "files/" link is the "files" directory the
+// wordlist already found, and requesting both reports it twice.
+func visitKey(path string) string {
+	path, query, hasQuery := strings.Cut(strings.TrimPrefix(path, "/"), "?")
+	path = strings.TrimRight(path, "/")
+	if hasQuery {
+		return path + "?" + query
+	}
+	return path
+}
+
+// markVisited records a path as seen and reports whether it was new. Once
+// the server profile is known, paths the server would resolve to the same
+// resource (case or slash variants) count as the same path.
+func (s *Scanner) markVisited(path string) bool {
+	key := visitKey(path)
+
+	s.pathMutex.Lock()
+	defer s.pathMutex.Unlock()
+
+	if s.visitedPaths[key] {
+		return false
+	}
+	if normalized := s.profile.Normalize(key); normalized != key {
+		if s.visitedPaths[normalized] {
+			s.profile.Deduped++
+			return false
+		}
+		s.visitedPaths[normalized] = true
+	}
+	s.visitedPaths[key] = true
+	return true
+}
+
+// isVisited reports whether markVisited would reject a path, without
+// recording it
+func (s *Scanner) isVisited(path string) bool {
+	key := visitKey(path)
+
+	s.pathMutex.Lock()
+	defer s.pathMutex.Unlock()
+
+	return s.visitedPaths[key] || s.visitedPaths[s.profile.Normalize(key)]
+}
+
+// pathSource returns where a queued path came from (wordlist by default)
+func (s *Scanner) pathSource(path string) string {
+	s.pathMutex.Lock()
+	defer s.pathMutex.Unlock()
+
+	if source, ok := s.pathSources[visitKey(path)]; ok {
+		return source
+	}
+	return SourceWordlist
+}
+
+// EnqueuePath adds a newly discovered path to the running scan. Paths that
+// were already visited are ignored. Returns true if the path was queued.
+func (s *Scanner) EnqueuePath(path, source string) bool {
+	queue := s.queue
+	if queue == nil || path == "" {
+		return false
+	}
+
+	s.cancelMutex.Lock()
+	cancelled := s.cancelScan
+	s.cancelMutex.Unlock()
+	if cancelled {
+		return false
+	}
+
+	if s.isExcluded(path) || !s.markVisited(path) {
+		return false
+	}
+
+	s.pathMutex.Lock()
+	s.pathSources[visitKey(path)] = source
+	s.pathMutex.Unlock()
+
+	atomic.AddInt64(&s.LiveStats.TotalRequests, 1)
+	queue.Push(QueuedPath{Path: path, Source: source})
+	return true
+}
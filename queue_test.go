@@ -0,0 +1,305 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// popAsync pops from the queue in a goroutine, so a test can check whether
+// Pop is still blocked
+func popAsync(q *scanQueue) <-chan QueuedPath {
+	ch := make(chan QueuedPath, 1)
+	go func() {
+		if item, ok := q.Pop(); ok {
+			ch <- item
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func expectBlocked(t *testing.T, ch <-chan QueuedPath, what string) {
+	t.Helper()
+	select {
+	case item, ok := <-ch:
+		t.Fatalf("%s returned early (%+v, %v)", what, item, ok)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func expectPopped(t *testing.T, ch <-chan QueuedPath) (QueuedPath, bool) {
+	t.Helper()
+	select {
+	case item, ok := <-ch:
+		return item, ok
+	case <-time.After(time.Second):
+		t.Fatal("Pop still blocked")
+	}
+	return QueuedPath{}, false
+}
+
+func TestScanQueueDrainsWhenEmpty(t *testing.T) {
+	q := newScanQueue(OrderBFS)
+	if _, ok := q.Pop(); ok {
+		t.Fatal("Pop on an empty, idle queue returned an item")
+	}
+}
+
+func TestScanQueuePopWaitsForInflight(t *testing.T) {
+	q := newScanQueue(OrderBFS)
+	q.Push(QueuedPath{Path: "a"})
+
+	item, ok := q.Pop()
+	if !ok || item.Path != "a" {
+		t.Fatalf("Pop = %+v, %v", item, ok)
+	}
+
+	// "a" is still being processed and might push more, so the queue
+	// isn't drained yet
+	ch := popAsync(q)
+	expectBlocked(t, ch, "Pop with an item in flight")
+
+	q.Push(QueuedPath{Path: "a/b", Depth: 1})
+	if child, ok := expectPopped(t, ch); !ok || child.Path != "a/b" {
+		t.Fatalf("blocked Pop got %+v, %v, want a/b", child, ok)
+	}
+
+	// Both in flight: finishing one still leaves the queue open
+	ch = popAsync(q)
+	q.Done(item)
+	expectBlocked(t, ch, "Pop with one item still in flight")
+	q.Done(QueuedPath{Path: "a/b", Depth: 1})
+	if _, ok := expectPopped(t, ch); ok {
+		t.Fatal("Pop returned an item from a drained queue")
+	}
+}
+
+func TestScanQueueHoldRelease(t *testing.T) {
+	q := newScanQueue(OrderBFS)
+	q.Hold()
+
+	ch := popAsync(q)
+	expectBlocked(t, ch, "Pop during a Hold")
+
+	q.Push(QueuedPath{Path: "generated"})
+	item, ok := expectPopped(t, ch)
+	if !ok || item.Path != "generated" {
+		t.Fatalf("Pop = %+v, %v", item, ok)
+	}
+	q.Done(item)
+
+	ch = popAsync(q)
+	expectBlocked(t, ch, "Pop during a Hold after Done")
+	q.Release()
+	if _, ok := expectPopped(t, ch); ok {
+		t.Fatal("Pop returned an item after Release")
+	}
+}
+
+func TestScanQueueWaitBelow(t *testing.T) {
+	q := newScanQueue(OrderBFS)
+	q.Push(QueuedPath{Path: "a"}, QueuedPath{Path: "b"}, QueuedPath{Path: "c"})
+
+	released := make(chan struct{})
+	go func() {
+		q.WaitBelow(2)
+		close(released)
+	}()
+
+	select {
+	case <-released:
+		t.Fatal("WaitBelow(2) returned with 3 items waiting")
+	case <-time.After(20 * time.Millisecond):
+	}
+	q.Pop()
+	select {
+	case <-released:
+		t.Fatal("WaitBelow(2) returned with 2 items waiting")
+	case <-time.After(20 * time.Millisecond):
+	}
+	q.Pop()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("WaitBelow(2) still blocked with 1 item waiting")
+	}
+
+	// Clear wakes a waiting producer too
+	q.Push(QueuedPath{Path: "d"}, QueuedPath{Path: "e"})
+	released = make(chan struct{})
+	go func() {
+		q.WaitBelow(2)
+		close(released)
+	}()
+	q.Clear()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("WaitBelow still blocked after Clear")
+	}
+}
+
+func TestScanQueueClear(t *testing.T) {
+	q := newScanQueue(OrderBFS)
+	q.Push(QueuedPath{Path: "a"}, QueuedPath{Path: "b"}, QueuedPath{Path: "a/x", Depth: 1})
+	item, _ := q.Pop()
+	q.Done(item)
+	q.AddDir(0)
+	q.AddOverBudget(1)
+
+	if dropped := q.Clear(); dropped != 2 {
+		t.Errorf("Clear dropped %d, want 2", dropped)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len after Clear = %d", q.Len())
+	}
+	want := []DepthProgress{
+		{Depth: 0, Done: 2, Queued: 2, Dirs: 1},
+		{Depth: 1, Done: 1, Queued: 1, Over: 1},
+	}
+	if got := q.Progress(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Progress after Clear = %+v, want %+v", got, want)
+	}
+	if _, ok := q.Pop(); ok {
+		t.Error("Pop after Clear returned an item")
+	}
+}
+
+func TestScanQueueOrder(t *testing.T) {
+	push := func(q *scanQueue) {
+		q.Push(
+			QueuedPath{Path: "a", Depth: 0},
+			QueuedPath{Path: "a/b", Depth: 1},
+			QueuedPath{Path: "c", Depth: 0},
+			QueuedPath{Path: "a/b/d", Depth: 2},
+			QueuedPath{Path: "a/e", Depth: 1},
+		)
+	}
+	drain := func(q *scanQueue) []string {
+		var paths []string
+		for {
+			item, ok := q.Pop()
+			if !ok {
+				return paths
+			}
+			paths = append(paths, item.Path)
+			q.Done(item)
+		}
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{OrderBFS, []string{"a", "c", "a/b", "a/e", "a/b/d"}},
+		{OrderDFS, []string{"a/b/d", "a/b", "a/e", "a", "c"}},
+	}
+	for _, tt := range tests {
+		q := newScanQueue(tt.order)
+		push(q)
+		if got := drain(q); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s order = %q, want %q", tt.order, got, tt.want)
+		}
+	}
+
+	// SetOrder applies to what is still queued
+	q := newScanQueue(OrderBFS)
+	push(q)
+	item, _ := q.Pop()
+	q.Done(item)
+	q.SetOrder(OrderDFS)
+	if got, want := drain(q), []string{"a/b/d", "a/b", "a/e", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after SetOrder(dfs) = %q, want %q", got, want)
+	}
+}
+
+// Workers expand directories while they hold an item, so the children are
+// pushed before Done and the queue can't drain underneath them
+func TestScanQueueExpandBeforeDone(t *testing.T) {
+	s := newTestScanner(t, "http://example.com", &Config{Recursive: true, RecursionDepth: 3})
+	s.queue = newScanQueue(OrderBFS)
+	s.recursionWords = []string{"x", "y"}
+
+	var roots []QueuedPath
+	for _, p := range []string{"a", "b", "c"} {
+		s.markVisited(p)
+		roots = append(roots, QueuedPath{Path: p, Source: SourceWordlist})
+	}
+	s.queue.Push(roots...)
+
+	var mu sync.Mutex
+	var scanned []string
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				item, ok := s.queue.Pop()
+				if !ok {
+					return
+				}
+				mu.Lock()
+				scanned = append(scanned, item.Path)
+				mu.Unlock()
+				if relativeDepth(item.Path) <= 3 {
+					s.expandDirectory(item)
+				}
+				s.queue.Done(item)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 3 roots, each with 2 + 4 + 8 descendants down to depth 3
+	if len(scanned) != 3*(1+2+4+8) {
+		t.Errorf("scanned %d paths, want %d", len(scanned), 3*(1+2+4+8))
+	}
+	sort.Strings(scanned)
+	for i := 1; i < len(scanned); i++ {
+		if scanned[i] == scanned[i-1] {
+			t.Errorf("%s scanned twice", scanned[i])
+		}
+	}
+	for _, p := range s.queue.Progress() {
+		if p.Done != p.Queued {
+			t.Errorf("depth %d: %d of %d done", p.Depth, p.Done, p.Queued)
+		}
+	}
+}
+
+func TestVisitKey(t *testing.T) {
+	tests := map[string]string{
+		"admin":          "admin",
+		"/admin":         "admin",
+		"/files/":        "files",
+		"files//":        "files",
+		"a/b/?page=2":    "a/b?page=2",
+		"search?q=a/":    "search?q=a/",
+		"/":              "",
+		"/api/v1/users/": "api/v1/users",
+	}
+	for p, want := range tests {
+		if got := visitKey(p); got != want {
+			t.Errorf("visitKey(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestMarkVisitedTrailingSlash(t *testing.T) {
+	s := newTestScanner(t, "http://example.com", &Config{})
+	if !s.markVisited("files") {
+		t.Fatal("first visit rejected")
+	}
+	for _, p := range []string{"/files", "files/", "/files/"} {
+		if s.markVisited(p) {
+			t.Errorf("markVisited(%q) after files = true", p)
+		}
+	}
+	if !s.markVisited("files/index.html") {
+		t.Error("child of a visited directory rejected")
+	}
+}
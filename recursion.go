@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ===========================================================================
+// RECURSIVE FRONTIER
+// ===========================================================================
+
+// Presets the F4 menu cycles through for the recursion status set and the
+// per-directory budget
+var (
+	recursionStatusPresets = [][]int{nil, {200}, {200, 403}, {200, 401, 403}, {200, 301, 302, 403}}
+	recursionBudgetPresets = []int{0, 100, 250, 500, 1000, 2500, 5000}
+)
+
+// relativeDepth is how many directory levels a path sits below the base
+// URL's path. Queued paths are always relative to the base, so a target like
+// https://host/app/v2 starts at depth 0 rather than 2.
+func relativeDepth(p string) int {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return 0
+	}
+	return strings.Count(p, "/") + 1
+}
+
+// matchPathPattern matches a glob (path.Match syntax) against a relative
+// path and each of its parent directories, so "static" also covers
+// static/css/site.css and "*/node_modules" any second-level node_modules
+// tree.
+func matchPathPattern(pattern, p string) bool {
+	pattern = strings.Trim(pattern, "/")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := range segments {
+		if ok, _ := path.Match(pattern, strings.Join(segments[:i+1], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setRecursion applies an F4 menu change to the recursion settings. Workers
+// read them mid-scan under recursionMutex; the TUI goroutine, the only
+// writer, reads them without it.
+func (s *Scanner) setRecursion(change func(config *Config)) {
+	s.recursionMutex.Lock()
+	defer s.recursionMutex.Unlock()
+	change(s.Config)
+}
+
+// recursionEnabled reports whether -recursive is on right now
+func (s *Scanner) recursionEnabled() bool {
+	s.recursionMutex.RLock()
+	defer s.recursionMutex.RUnlock()
+	return s.Config.Recursive
+}
+
+// isExcluded reports whether a path falls under a -exclude pattern. Excluded
+// paths are neither recursed into nor queued from crawling or other discovery.
+func (s *Scanner) isExcluded(p string) bool {
+	s.recursionMutex.RLock()
+	defer s.recursionMutex.RUnlock()
+	return s.excludedLocked(p)
+}
+
+// excludedLocked is isExcluded for callers already holding recursionMutex
+func (s *Scanner) excludedLocked(p string) bool {
+	for _, pattern := range s.Config.RecursionExclude {
+		if matchPathPattern(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRecurse decides whether a result's children get scanned: it must be
+// a directory, answer with a status in -recurse-status (any, if unset),
+// match -recurse-include (if set), not be excluded, and sit within -depth.
+// For the status, the first response counts as well as the final one, so
+// "301" matches a directory that redirects to its slash form.
+func (s *Scanner) shouldRecurse(result *ScanResult) bool {
+	return s.recursionEnabled() && result.Kind == KindDirectory && s.recursionAllowed(result)
+}
+
+// recursionAllowed is shouldRecurse without the directory check, so
+// classification can skip probing results that would never be recursed
+func (s *Scanner) recursionAllowed(result *ScanResult) bool {
+	s.recursionMutex.RLock()
+	defer s.recursionMutex.RUnlock()
+
+	if len(s.Config.RecursionStatuses) > 0 {
+		matched := false
+		for _, status := range s.Config.RecursionStatuses {
+			if result.FinalStatus == status || (len(result.RedirectChain) > 0 && result.RedirectChain[0].Status == status) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	dir := strings.Trim(result.OriginalPath, "/")
+	if len(s.Config.RecursionInclude) > 0 {
+		included := false
+		for _, pattern := range s.Config.RecursionInclude {
+			if matchPathPattern(pattern, dir) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	if s.excludedLocked(dir) {
+		return false
+	}
+	return relativeDepth(dir) <= s.Config.RecursionDepth
+}
+
+// expandDirectory pushes the wordlist under a directory hit onto the
+// frontier, one level deeper than the hit. Children beyond -depth, excluded
+// or already-visited paths are skipped, and at most -dir-budget children are
+// queued per directory (the rest are counted as over budget). Apart from
+// that the frontier has no size limit. Returns how many children were
+// queued.
+func (s *Scanner) expandDirectory(parent QueuedPath) int {
+	s.cancelMutex.Lock()
+	cancelled := s.cancelScan
+	s.cancelMutex.Unlock()
+	if cancelled {
+		return 0
+	}
+
+	s.recursionMutex.RLock()
+	depth, budget := s.Config.RecursionDepth, s.Config.RecursionBudget
+	s.recursionMutex.RUnlock()
+
+	base := strings.TrimSuffix(parent.Path, "/")
+	var children []QueuedPath
+	for _, word := range s.recursionWords {
+		child := base + "/" + strings.TrimPrefix(word, "/")
+		if relativeDepth(child)-1 > depth || s.isExcluded(child) {
+			continue
+		}
+		// Over budget means a new child left out: paths already visited
+		// through another source don't count
+		if budget > 0 && len(children) >= budget {
+			if s.isVisited(child) {
+				continue
+			}
+			s.queue.AddOverBudget(parent.Depth + 1)
+			continue
+		}
+		if !s.markVisited(child) {
+			continue
+		}
+		children = append(children, QueuedPath{Path: child, Source: SourceWordlist, Depth: parent.Depth + 1})
+	}
+
+	if len(children) == 0 {
+		return 0
+	}
+	s.queue.AddDir(parent.Depth)
+	atomic.AddInt64(&s.LiveStats.TotalRequests, int64(len(children)))
+	s.queue.Push(children...)
+	return len(children)
+}
+
+// DepthProgress returns per-level progress of the running (or last) scan
+func (s *Scanner) DepthProgress() []DepthProgress {
+	if s.queue == nil {
+		return nil
+	}
+	return s.queue.Progress()
+}
+
+// depthProgressText is the one-line per-depth summary for the dashboard
+func depthProgressText(progress []DepthProgress) string {
+	parts := make([]string, len(progress))
+	for i, p := range progress {
+		parts[i] = fmt.Sprintf("D%d %d/%d", p.Depth, p.Done, p.Queued)
+	}
+	return "Depth: " + strings.Join(parts, " | ")
+}
+
+// formatStatusSet renders a recursion status set for the F4 menu
+func formatStatusSet(statuses []int) string {
+	if len(statuses) == 0 {
+		return "any"
+	}
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = strconv.Itoa(status)
+	}
+	return strings.Join(parts, ",")
+}
+
+// cycleStatusPreset moves the recursion status set to the next or previous
+// preset (a custom set from the command line starts from "any")
+func cycleStatusPreset(current []int, delta int) []int {
+	idx := 0
+	for i, preset := range recursionStatusPresets {
+		if formatStatusSet(preset) == formatStatusSet(current) {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(recursionStatusPresets)) % len(recursionStatusPresets)
+	return recursionStatusPresets[idx]
+}
+
+// cycleBudgetPreset moves the per-directory budget to the next or previous
+// preset
+func cycleBudgetPreset(current, delta int) int {
+	idx := 0
+	for i, preset := range recursionBudgetPresets {
+		if preset == current {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(recursionBudgetPresets)) % len(recursionBudgetPresets)
+	return recursionBudgetPresets[idx]
+}
+
+// validRecursionOrder falls back to breadth-first for unknown orders
+func validRecursionOrder(order string) string {
+	if strings.EqualFold(order, OrderDFS) {
+		return OrderDFS
+	}
+	return OrderBFS
+}
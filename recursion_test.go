@@ -0,0 +1,94 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"static", "static", true},
+		{"static", "static/css/site.css", true},
+		{"/static/", "static/css", true},
+		{"static", "assets/static", false},
+		{"*/node_modules", "app/node_modules/x/index.js", true},
+		{"*/node_modules", "node_modules", false},
+		{"api/v?", "api/v2/users", true},
+	}
+	for _, tt := range tests {
+		if got := matchPathPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchPathPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRecursionAllowed(t *testing.T) {
+	config := &Config{
+		Recursive:         true,
+		RecursionDepth:    2,
+		RecursionStatuses: []int{200, 301},
+		RecursionInclude:  []string{"app", "admin"},
+		RecursionExclude:  []string{"app/static"},
+	}
+	s := newTestScanner(t, "http://example.com", config)
+
+	tests := []struct {
+		path   string
+		status int
+		chain  []RedirectStep
+		want   bool
+	}{
+		{"/app", 200, nil, true},
+		{"/app/v1", 200, nil, true},
+		{"/app/v1/deep", 200, nil, false}, // Below -depth
+		{"/app/static", 200, nil, false},  // Excluded
+		{"/other", 200, nil, false},       // Not included
+		{"/admin", 403, nil, false},       // Status not in the set
+		{"/admin", 200, []RedirectStep{{Status: 301}}, true},
+	}
+	for _, tt := range tests {
+		result := &ScanResult{OriginalPath: tt.path, FinalStatus: tt.status, RedirectChain: tt.chain, Kind: KindDirectory}
+		if got := s.recursionAllowed(result); got != tt.want {
+			t.Errorf("recursionAllowed(%s %d) = %v, want %v", tt.path, tt.status, got, tt.want)
+		}
+		if got := s.shouldRecurse(result); got != tt.want {
+			t.Errorf("shouldRecurse(%s %d) = %v, want %v", tt.path, tt.status, got, tt.want)
+		}
+	}
+
+	s.setRecursion(func(config *Config) { config.Recursive = false })
+	if s.shouldRecurse(&ScanResult{OriginalPath: "/app", FinalStatus: 200, Kind: KindDirectory}) {
+		t.Error("shouldRecurse with -recursive off")
+	}
+}
+
+// The F4 menu changes the recursion settings while workers read them; run
+// with -race
+func TestSetRecursionConcurrent(t *testing.T) {
+	s := newTestScanner(t, "http://example.com", &Config{Recursive: true, RecursionDepth: 3})
+	result := &ScanResult{OriginalPath: "/app", FinalStatus: 200, Kind: KindDirectory}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s.shouldRecurse(result)
+			s.isExcluded("app/static")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s.setRecursion(func(config *Config) {
+				config.RecursionExclude = []string{"static"}
+				config.RecursionStatuses = cycleStatusPreset(config.RecursionStatuses, 1)
+				config.RecursionBudget = cycleBudgetPreset(config.RecursionBudget, 1)
+			})
+		}
+	}()
+	wg.Wait()
+}
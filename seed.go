@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ===========================================================================
+// ROBOTS / SITEMAP / WELL-KNOWN SEEDING
+// ===========================================================================
+
+const (
+	SourceRobots    = "robots"
+	SourceSitemap   = "sitemap"
+	SourceWellKnown = "well-known"
+
+	MaxSitemapDocuments = 50
+	MaxSitemapBytes     = 50 * 1024 * 1024 // Sitemap protocol limit, uncompressed
+)
+
+// Files worth checking under /.well-known/ (and security.txt's legacy spot)
+var wellKnownPaths = []string{
+	"/.well-known/security.txt",
+	"/security.txt",
+	"/.well-known/openid-configuration",
+	"/.well-known/oauth-authorization-server",
+	"/.well-known/change-password",
+	"/.well-known/assetlinks.json",
+	"/.well-known/apple-app-site-association",
+	"/.well-known/host-meta",
+	"/.well-known/webfinger",
+	"/.well-known/mta-sts.txt",
+	"/.well-known/dnt-policy.txt",
+	"/.well-known/jwks.json",
+}
+
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// SeedSummary counts what the pre-scan phase queued from each source
+type SeedSummary struct {
+	Robots    int
+	Sitemap   int
+	WellKnown int
+}
+
+func (s SeedSummary) Total() int {
+	return s.Robots + s.Sitemap + s.WellKnown
+}
+
+// SeedFromWellKnown is the pre-scan phase: it reads robots.txt, sitemaps
+// (including indexes and .gz sitemaps) and .well-known files, and queues
+// every in-scope path they mention, tagged with where it came from.
+func (s *Scanner) SeedFromWellKnown() SeedSummary {
+	var summary SeedSummary
+
+	root, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return summary
+	}
+	root.Path = ""
+	root.RawQuery = ""
+	hostRoot := strings.TrimRight(root.String(), "/")
+
+	// robots.txt - Allow/Disallow entries plus any Sitemap: pointers
+	sitemaps := []string{hostRoot + "/sitemap.xml", hostRoot + "/sitemap_index.xml"}
+	if base := strings.TrimRight(s.BaseURL, "/"); base != hostRoot {
+		sitemaps = append(sitemaps, base+"/sitemap.xml")
+	}
+	if body, ok := s.fetchSeedFile(hostRoot + "/robots.txt"); ok {
+		paths, declared := ParseRobots(body)
+		summary.Robots += s.queueDiscovered([]string{hostRoot + "/robots.txt"}, SourceRobots)
+		var urls []string
+		for _, p := range paths {
+			urls = append(urls, hostRoot+p)
+		}
+		summary.Robots += s.queueDiscovered(urls, SourceRobots)
+		sitemaps = append(declared, sitemaps...)
+	}
+
+	// Sitemaps, following sitemap indexes breadth-first
+	fetched := make(map[string]bool)
+	for len(sitemaps) > 0 && len(fetched) < MaxSitemapDocuments {
+		sitemapURL := sitemaps[0]
+		sitemaps = sitemaps[1:]
+		if fetched[sitemapURL] {
+			continue
+		}
+		fetched[sitemapURL] = true
+
+		body, ok := s.fetchSeedFile(sitemapURL)
+		if !ok {
+			continue
+		}
+		doc, err := ParseSitemap(body)
+		if err != nil {
+			continue
+		}
+
+		summary.Sitemap += s.queueDiscovered([]string{sitemapURL}, SourceSitemap)
+		var urls []string
+		for _, u := range doc.URLs {
+			urls = append(urls, strings.TrimSpace(u.Loc))
+		}
+		summary.Sitemap += s.queueDiscovered(urls, SourceSitemap)
+		for _, child := range doc.Sitemaps {
+			sitemaps = append(sitemaps, strings.TrimSpace(child.Loc))
+		}
+	}
+
+	// .well-known files, plus the URLs security.txt points at
+	for _, p := range wellKnownPaths {
+		body, ok := s.fetchSeedFile(hostRoot + p)
+		if !ok {
+			continue
+		}
+		summary.WellKnown += s.queueDiscovered([]string{hostRoot + p}, SourceWellKnown)
+		if strings.HasSuffix(p, "security.txt") {
+			summary.WellKnown += s.queueDiscovered(ParseSecurityTxt(body), SourceWellKnown)
+		}
+	}
+
+	return summary
+}
+
+// fetchSeedFile GETs a seed file and returns its body if it came back 200 and
+// isn't the wildcard catch-all page.
+func (s *Scanner) fetchSeedFile(fileURL string) ([]byte, bool) {
+	result, err := s.fetchWithOptions(fileURL, requestOptions{Method: "GET"})
+	if err != nil || result.FinalStatus != 200 || len(result.body) == 0 {
+		return nil, false
+	}
+	if s.IsWildcardResponse(result) {
+		return nil, false
+	}
+	return result.body, true
+}
+
+// ParseRobots returns the Allow/Disallow paths and Sitemap URLs in a
+// robots.txt. Wildcard rules are cut at the first '*' so the static prefix
+// is still usable.
+func ParseRobots(body []byte) (paths []string, sitemaps []string) {
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "allow", "disallow":
+			if i := strings.Index(value, "*"); i >= 0 {
+				value = value[:i]
+			}
+			value = strings.TrimSuffix(value, "$")
+			if value == "" || value == "/" || !strings.HasPrefix(value, "/") || seen[value] {
+				continue
+			}
+			seen[value] = true
+			paths = append(paths, value)
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		}
+	}
+	return paths, sitemaps
+}
+
+// ParseSitemap decodes a <urlset> or <sitemapindex>, gunzipping it first if
+// it is gzip-compressed (sitemap.xml.gz, or served with gzip bytes).
+func ParseSitemap(body []byte) (*sitemapDocument, error) {
+	if len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body, err = io.ReadAll(io.LimitReader(gz, MaxSitemapBytes))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// ParseSecurityTxt returns the URLs listed in a security.txt (Contact,
+// Policy, Acknowledgments, Hiring, Canonical, Encryption)
+func ParseSecurityTxt(body []byte) []string {
+	var urls []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+			urls = append(urls, value)
+		}
+	}
+	return urls
+}
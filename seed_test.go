@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseRobots(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		paths    []string
+		sitemaps []string
+	}{
+		{
+			name:  "allow and disallow",
+			body:  "User-agent: *\nDisallow: /admin/\nAllow: /public\n",
+			paths: []string{"/admin/", "/public"},
+		},
+		{
+			name:  "wildcards cut at the first star",
+			body:  "Disallow: /search*\nDisallow: /*.php$\nDisallow: /files/*/private\n",
+			paths: []string{"/search", "/files/"},
+		},
+		{
+			name:  "anchor stripped",
+			body:  "Disallow: /login.php$\n",
+			paths: []string{"/login.php"},
+		},
+		{
+			name:  "comments, root and relative values skipped",
+			body:  "# robots\nDisallow: /tmp # scratch\nDisallow: /\nDisallow:\nAllow: relative\n",
+			paths: []string{"/tmp"},
+		},
+		{
+			name:  "duplicates and field case",
+			body:  "DISALLOW: /a\nuser-agent: bot\ndisallow: /a\n",
+			paths: []string{"/a"},
+		},
+		{
+			name:     "sitemaps",
+			body:     "Sitemap: https://example.com/sitemap.xml\nsitemap: https://example.com/news.xml.gz\nSitemap:\n",
+			sitemaps: []string{"https://example.com/sitemap.xml", "https://example.com/news.xml.gz"},
+		},
+	}
+	for _, tt := range tests {
+		paths, sitemaps := ParseRobots([]byte(tt.body))
+		if !reflect.DeepEqual(paths, tt.paths) {
+			t.Errorf("%s: paths = %q, want %q", tt.name, paths, tt.paths)
+		}
+		if !reflect.DeepEqual(sitemaps, tt.sitemaps) {
+			t.Errorf("%s: sitemaps = %q, want %q", tt.name, sitemaps, tt.sitemaps)
+		}
+	}
+}
+
+const testURLSet = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc></url>
+  <url><loc> https://example.com/about </loc><lastmod>2024-01-01</lastmod></url>
+</urlset>`
+
+const testSitemapIndex = `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/sitemap-posts.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/sitemap-pages.xml.gz</loc></sitemap>
+</sitemapindex>`
+
+func gzipBytes(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sitemapLocs(locs []sitemapLoc) []string {
+	var out []string
+	for _, loc := range locs {
+		out = append(out, loc.Loc)
+	}
+	return out
+}
+
+func TestParseSitemap(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     []byte
+		urls     []string
+		sitemaps []string
+	}{
+		{
+			name: "urlset",
+			body: []byte(testURLSet),
+			urls: []string{"https://example.com/", " https://example.com/about "},
+		},
+		{
+			name:     "sitemap index",
+			body:     []byte(testSitemapIndex),
+			sitemaps: []string{"https://example.com/sitemap-posts.xml", "https://example.com/sitemap-pages.xml.gz"},
+		},
+		{
+			name: "gzipped urlset",
+			body: gzipBytes(t, testURLSet),
+			urls: []string{"https://example.com/", " https://example.com/about "},
+		},
+	}
+	for _, tt := range tests {
+		doc, err := ParseSitemap(tt.body)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := sitemapLocs(doc.URLs); !reflect.DeepEqual(got, tt.urls) {
+			t.Errorf("%s: urls = %q, want %q", tt.name, got, tt.urls)
+		}
+		if got := sitemapLocs(doc.Sitemaps); !reflect.DeepEqual(got, tt.sitemaps) {
+			t.Errorf("%s: sitemaps = %q, want %q", tt.name, got, tt.sitemaps)
+		}
+	}
+}
+
+func TestParseSitemapErrors(t *testing.T) {
+	for name, body := range map[string][]byte{
+		"not xml":     []byte("<html><body>Not Found"),
+		"broken gzip": {0x1f, 0x8b, 0x00, 0x01},
+	} {
+		if _, err := ParseSitemap(body); err == nil {
+			t.Errorf("%s: ParseSitemap succeeded, want an error", name)
+		}
+	}
+}
+
+func TestParseSecurityTxt(t *testing.T) {
+	body := "# Our security policy\nContact: mailto:security@example.com\nContact: https://example.com/report\nPolicy: https://example.com/policy\nExpires: 2030-01-01T00:00:00Z\n"
+	want := []string{"https://example.com/report", "https://example.com/policy"}
+	if got := ParseSecurityTxt([]byte(body)); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSecurityTxt = %q, want %q", got, want)
+	}
+}
+
+func TestScanAllSeedSourceWins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /admin\n"))
+		case "/admin", "/about":
+			w.Write([]byte("page " + r.URL.Path))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	s := newTestScanner(t, server.URL, &Config{Seed: true})
+	sources := make(map[string]string)
+	for _, result := range s.ScanAll([]string{"admin", "about"}, nil) {
+		sources[result.OriginalPath] = result.Source
+	}
+	if sources["/admin"] != SourceRobots {
+		t.Errorf("/admin source = %q, want %q", sources["/admin"], SourceRobots)
+	}
+	if sources["/about"] != SourceWordlist {
+		t.Errorf("/about source = %q, want %q", sources["/about"], SourceWordlist)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// SENSITIVE CONTENT DETECTION
+// ===========================================================================
+
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityMedium:
+		return "MEDIUM"
+	case SeverityLow:
+		return "LOW"
+	}
+	return "INFO"
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// MaxSensitiveScanBytes limits how much of each body the rules look at
+const MaxSensitiveScanBytes = 2 * 1024 * 1024
+
+type SensitiveRule struct {
+	Name     string
+	Severity Severity
+	Pattern  *regexp.Regexp
+}
+
+// SensitiveFinding is one rule match in one response
+type SensitiveFinding struct {
+	Rule     string
+	Severity Severity
+	Match    string // redacted excerpt of what matched
+}
+
+var SensitiveRules = []SensitiveRule{
+	// Keys and credentials
+	{"Private key", SeverityCritical, regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{"AWS secret access key", SeverityCritical, regexp.MustCompile(`(?i)aws.{0,20}(?:secret|private).{0,20}['"][0-9a-zA-Z/+]{40}['"]`)},
+	{"Stripe live secret key", SeverityCritical, regexp.MustCompile(`\b[rs]k_live_[0-9a-zA-Z]{24,}\b`)},
+	{"AWS access key ID", SeverityHigh, regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA)[0-9A-Z]{16}\b`)},
+	{"Google API key", SeverityHigh, regexp.MustCompile(`\bAIza[0-9A-Za-z\-_]{35}\b`)},
+	{"GitHub token", SeverityHigh, regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"Slack token", SeverityHigh, regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}\b`)},
+	{"Database connection string", SeverityHigh, regexp.MustCompile(`(?i)\b(?:mysql|postgres(?:ql)?|mongodb(?:\+srv)?|redis|mssql|sqlserver|mariadb|amqps?)://[^\s:@/'"<>]+:[^\s@/'"<>]+@[^\s'"<>]+`)},
+	{"JDBC connection string", SeverityMedium, regexp.MustCompile(`(?i)\bjdbc:[a-z0-9]+:(?://)?[^\s'"<>]{6,}`)},
+	{"Password assignment", SeverityMedium, regexp.MustCompile(`(?i)\b(?:password|passwd|db_pass(?:word)?|secret_key|api_secret)\s*[=:]\s*['"]?[^\s'"<>;,]{4,}`)},
+	{"JSON Web Token", SeverityMedium, regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+
+	// Errors and stack traces that leak internals
+	{"SQL error message", SeverityHigh, regexp.MustCompile(`(?i)(?:You have an error in your SQL syntax|Warning: mysqli?_|ORA-\d{5}:|PG::SyntaxError|SQLSTATE\[|Unclosed quotation mark after the character string|SQLite3::(?:query|SQLException)|Microsoft OLE DB Provider for SQL Server)`)},
+	{"PHP error", SeverityMedium, regexp.MustCompile(`(?i)(?:<b>(?:Fatal error|Parse error|Warning|Notice|Deprecated)</b>:\s+.{1,300}? in <b>[^<]+</b> on line <b>\d+</b>|PHP (?:Fatal error|Parse error|Warning):\s+.{1,300}? in \S+ on line \d+)`)},
+	{"ASP.NET error page", SeverityMedium, regexp.MustCompile(`(?:Server Error in '[^']*' Application|<title>Runtime Error</title>|\[(?:[A-Z][A-Za-z]+\.)*[A-Z][A-Za-z]*Exception: )`)},
+	{"Java stack trace", SeverityMedium, regexp.MustCompile(`(?:[a-zA-Z_$][\w$]*\.)+[A-Z][\w$]*(?:Exception|Error)(?::[^\n]*)?\s+at [\w$.]+\([\w$]+\.java:\d+\)`)},
+	{"Python traceback", SeverityMedium, regexp.MustCompile(`Traceback \(most recent call last\):\s+File "[^"]+", line \d+`)},
+	{".NET stack trace", SeverityMedium, regexp.MustCompile(`\bat [\w.<>` + "`" + `]+\([^)]*\) in [^\n]+:line \d+`)},
+	{"Node.js stack trace", SeverityMedium, regexp.MustCompile(`\bat (?:[\w.<>]+ )?\((?:/|[A-Z]:\\)[^)]+\.(?:js|ts|mjs):\d+:\d+\)`)},
+	{"Framework debug page", SeverityMedium, regexp.MustCompile(`(?:You're seeing this error because you have <code>DEBUG = True</code>|<title>Whoops! There was an error\.</title>|Werkzeug Debugger</title>|<h1>Whitelabel Error Page</h1>)`)},
+
+	// Low-grade leaks
+	{"Internal IP address", SeverityLow, regexp.MustCompile(`\b(?:10\.\d{1,3}|192\.168|172\.(?:1[6-9]|2\d|3[01]))\.\d{1,3}\.\d{1,3}\b`)},
+}
+
+// DetectSensitiveContent runs every rule over a body and returns one finding
+// per rule that matched, most severe first.
+func DetectSensitiveContent(body []byte) []SensitiveFinding {
+	if len(body) > MaxSensitiveScanBytes {
+		body = body[:MaxSensitiveScanBytes]
+	}
+
+	var findings []SensitiveFinding
+	for _, rule := range SensitiveRules {
+		match := rule.Pattern.Find(body)
+		if match == nil {
+			continue
+		}
+		findings = append(findings, SensitiveFinding{
+			Rule:     rule.Name,
+			Severity: rule.Severity,
+			Match:    redactMatch(string(match)),
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
+
+// redactMatch keeps enough of a match to recognize it in the report without
+// writing the whole secret to disk again.
+func redactMatch(match string) string {
+	match = strings.Join(strings.Fields(match), " ")
+	if len(match) > 120 {
+		match = match[:120]
+	}
+	if len(match) <= 16 {
+		return match
+	}
+	keep := len(match) / 4
+	if keep > 12 {
+		keep = 12
+	}
+	return strings.ToValidUTF8(match[:keep]+"..."+match[len(match)-4:], "")
+}
+
+// detectSensitive scans a kept result's body and records any findings
+func (s *Scanner) detectSensitive(result *ScanResult) {
+	if len(result.body) == 0 {
+		return
+	}
+
+	findings := DetectSensitiveContent(result.body)
+	if len(findings) == 0 {
+		return
+	}
+	result.Findings = findings
+
+	s.Stats.mu.Lock()
+	s.Stats.Sensitive = append(s.Stats.Sensitive, result)
+	s.Stats.mu.Unlock()
+}
+
+// MaxSeverity returns the most severe finding on a result (0 if none)
+func (r *ScanResult) MaxSeverity() Severity {
+	var max Severity
+	for _, f := range r.Findings {
+		if f.Severity > max {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// findingsTag is the short tag shown next to a result in the live view
+func findingsTag(result *ScanResult) string {
+	if len(result.Findings) == 0 {
+		return ""
+	}
+	tag := fmt.Sprintf("[!%s %s", result.MaxSeverity(), result.Findings[0].Rule)
+	if len(result.Findings) > 1 {
+		tag += fmt.Sprintf(" +%d", len(result.Findings)-1)
+	}
+	return tag + "]"
+}
+
+// SortedSensitive returns results with findings, most severe first
+func (s *Scanner) SortedSensitive() []*ScanResult {
+	s.Stats.mu.Lock()
+	sorted := make([]*ScanResult, len(s.Stats.Sensitive))
+	copy(sorted, s.Stats.Sensitive)
+	s.Stats.mu.Unlock()
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].MaxSeverity() != sorted[j].MaxSeverity() {
+			return sorted[i].MaxSeverity() > sorted[j].MaxSeverity()
+		}
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+	return sorted
+}
+
+func formatFindings(findings []SensitiveFinding) string {
+	var parts []string
+	for _, f := range findings {
+		parts = append(parts, fmt.Sprintf("%s: %s", f.Severity, f.Rule))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===========================================================================
+// RESPONSE STORE
+// ===========================================================================
+
+const (
+	StoreIndexFile = "index.jsonl"
+
+	// MaxViewerBytes caps how much of a stored body the TUI viewer loads
+	MaxViewerBytes = 256 * 1024
+)
+
+var storeNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// ResponseStore keeps the request, response headers and body of every kept
+// result under one directory:
+//
+//	<dir>/responses/<n>-<path>.txt   request + status line + headers
+//	<dir>/bodies/<ab>/<hash>         body, stored once per ContentHash
+//	<dir>/index.jsonl                one StoreEntry per result
+type ResponseStore struct {
+	Dir string
+
+	mu       sync.Mutex
+	index    *os.File
+	bodies   map[string]bool
+	seq      int
+	failures int
+	lastErr  error
+}
+
+// StoreEntry is one line of the store index
+type StoreEntry struct {
+	Path      string    `json:"path"`
+	URL       string    `json:"url"`
+	Status    int       `json:"status"`
+	Hash      string    `json:"hash"`
+	Record    string    `json:"record"`
+	Body      string    `json:"body"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OpenResponseStore creates the store layout under dir (if needed) and
+// appends to its index, so several runs can share one store.
+func OpenResponseStore(dir string) (*ResponseStore, error) {
+	for _, sub := range []string{"responses", "bodies"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("creating response store: %v", err)
+		}
+	}
+
+	index, err := os.OpenFile(filepath.Join(dir, StoreIndexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening store index: %v", err)
+	}
+
+	rs := &ResponseStore{
+		Dir:    dir,
+		index:  index,
+		bodies: make(map[string]bool),
+	}
+
+	// Pick up numbering and known bodies from earlier runs
+	if entries, err := ReadStoreIndex(dir); err == nil {
+		rs.seq = len(entries)
+		for _, e := range entries {
+			rs.bodies[e.Hash] = true
+		}
+	}
+	return rs, nil
+}
+
+// Failures returns how many results couldn't be stored and the last error
+func (rs *ResponseStore) Failures() (int, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.failures, rs.lastErr
+}
+
+func (rs *ResponseStore) Close() error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.index.Close()
+}
+
+// Save writes a result's request/response record and its body (unless a
+// body with the same hash is already stored), then indexes it. The stored
+// file paths are recorded on the result.
+func (rs *ResponseStore) Save(result *ScanResult) (err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	defer func() {
+		if err != nil {
+			rs.failures++
+			rs.lastErr = err
+		}
+	}()
+
+	rs.seq++
+	name := strings.Trim(storeNameRegex.ReplaceAllString(result.OriginalPath, "_"), "_")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+	if name == "" {
+		name = "root"
+	}
+
+	recordPath := filepath.Join(rs.Dir, "responses", fmt.Sprintf("%06d-%s.txt", rs.seq, name))
+	bodyPath := filepath.Join(rs.Dir, "bodies", result.ContentHash[:2], result.ContentHash)
+
+	if !rs.bodies[result.ContentHash] {
+		if err := os.MkdirAll(filepath.Dir(bodyPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(bodyPath, result.body, 0644); err != nil {
+			return err
+		}
+		rs.bodies[result.ContentHash] = true
+	}
+
+	if err := os.WriteFile(recordPath, []byte(formatStoreRecord(result, bodyPath)), 0644); err != nil {
+		return err
+	}
+
+	entry := StoreEntry{
+		Path:      result.OriginalPath,
+		URL:       result.FinalURL,
+		Status:    result.FinalStatus,
+		Hash:      result.ContentHash,
+		Record:    recordPath,
+		Body:      bodyPath,
+		Timestamp: result.Timestamp,
+	}
+	line, _ := json.Marshal(entry)
+	if _, err := rs.index.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	result.StoredAs = recordPath
+	result.BodyFile = bodyPath
+	return nil
+}
+
+// formatStoreRecord renders the final request and response head as raw HTTP
+func formatStoreRecord(result *ScanResult, bodyPath string) string {
+	var b strings.Builder
+
+	if resp := result.response; resp != nil && resp.Request != nil {
+		req := resp.Request
+		b.WriteString(fmt.Sprintf("%s %s HTTP/1.1\r\n", req.Method, req.URL.RequestURI()))
+		b.WriteString(fmt.Sprintf("Host: %s\r\n", req.URL.Host))
+		writeSortedHeaders(&b, req.Header)
+		b.WriteString("\r\n")
+
+		b.WriteString(fmt.Sprintf("%s %s\r\n", resp.Proto, resp.Status))
+	} else {
+		b.WriteString(fmt.Sprintf("GET %s\r\n\r\n", result.FinalURL))
+		b.WriteString(fmt.Sprintf("HTTP/1.1 %d %s\r\n", result.FinalStatus, http.StatusText(result.FinalStatus)))
+	}
+	writeSortedHeaders(&b, result.header)
+	b.WriteString("\r\n")
+
+	// Where the body lives, plus how we got here
+	b.WriteString(fmt.Sprintf("# Body: %s (%d bytes)\n", bodyPath, result.ContentLength))
+	if len(result.RedirectChain) > 0 {
+		b.WriteString("# Redirect chain:\n")
+		for _, step := range result.RedirectChain {
+			b.WriteString(fmt.Sprintf("#   [%d] %s\n", step.Status, step.URL))
+		}
+		b.WriteString(fmt.Sprintf("#   [%d] %s\n", result.FinalStatus, result.FinalURL))
+	}
+	return b.String()
+}
+
+func writeSortedHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			b.WriteString(fmt.Sprintf("%s: %s\r\n", name, value))
+		}
+	}
+}
+
+// ReadStoreIndex loads every entry of a store's index
+func ReadStoreIndex(dir string) ([]StoreEntry, error) {
+	file, err := os.Open(filepath.Join(dir, StoreIndexFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []StoreEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry StoreEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// storeResult saves a kept result if a response store is configured.
+// Write errors are counted on the store and reported when PathFinder exits,
+// printing them mid-scan would tear up the TUI.
+func (s *Scanner) storeResult(result *ScanResult) {
+	if s.store != nil {
+		s.store.Save(result)
+	}
+}
+
+// LoadStoredResponse reads a stored record and body back as display lines
+// for the TUI viewer. Binary bytes are replaced so they can't upset the
+// terminal.
+func LoadStoredResponse(result *ScanResult) ([]string, error) {
+	if result.StoredAs == "" {
+		return nil, fmt.Errorf("response not stored (run with -store <dir>)")
+	}
+
+	record, err := os.ReadFile(result.StoredAs)
+	if err != nil {
+		return nil, err
+	}
+	body, err := os.ReadFile(result.BodyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := false
+	if len(body) > MaxViewerBytes {
+		body = body[:MaxViewerBytes]
+		truncated = true
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(record), "\r\n", "\n"), "\n") {
+		lines = append(lines, sanitizeViewerLine(line))
+	}
+	for _, line := range strings.Split(string(body), "\n") {
+		lines = append(lines, sanitizeViewerLine(strings.TrimSuffix(line, "\r")))
+	}
+	if truncated {
+		lines = append(lines, fmt.Sprintf("... (truncated at %d bytes, see %s)", MaxViewerBytes, result.BodyFile))
+	}
+	return lines, nil
+}
+
+func sanitizeViewerLine(line string) string {
+	line = strings.ToValidUTF8(line, "?")
+	line = strings.ReplaceAll(line, "\t", "    ")
+	return strings.Map(func(r rune) rune {
+		if r < 32 || r == 127 {
+			return '.'
+		}
+		return r
+	}, line)
+}
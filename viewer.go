@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ===========================================================================
+// STORED RESPONSE VIEWER
+// ===========================================================================
+
+// moveResultSelection moves the live results cursor by delta (Tab / Shift+Tab).
+// With nothing selected it starts from the newest result.
+func (tui *TUI) moveResultSelection(delta int) {
+	tui.scanner.resultsMutex.Lock()
+	results := tui.scanner.lastResults
+	tui.scanner.resultsMutex.Unlock()
+
+	if len(results) == 0 {
+		tui.selectedResult = nil
+		return
+	}
+
+	idx := len(results)
+	for i, result := range results {
+		if result == tui.selectedResult {
+			idx = i
+			break
+		}
+	}
+
+	idx += delta
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(results) {
+		idx = len(results) - 1
+	}
+	tui.selectedResult = results[idx]
+}
+
+// openResponseViewer loads the selected result's stored response (or the
+// newest result's, if nothing is selected) into the viewer overlay.
+func (tui *TUI) openResponseViewer() {
+	result := tui.selectedResult
+	if result == nil {
+		tui.scanner.resultsMutex.Lock()
+		if n := len(tui.scanner.lastResults); n > 0 {
+			result = tui.scanner.lastResults[n-1]
+		}
+		tui.scanner.resultsMutex.Unlock()
+	}
+	if result == nil {
+		return
+	}
+
+	lines, err := LoadStoredResponse(result)
+	if err != nil {
+		lines = []string{"Error: " + err.Error()}
+	}
+	tui.viewerTitle = fmt.Sprintf("[%d] %s", result.FinalStatus, result.OriginalPath)
+	tui.viewerLines = lines
+	tui.viewerScroll = 0
+	tui.showResponseViewer = true
+}
+
+func (tui *TUI) renderResponseViewer() {
+	viewWidth := tui.width - 8
+	viewHeight := tui.height - 4
+	viewX := 4
+	viewY := 2
+
+	// Fill background
+	bgStyle := tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Text)
+	for y := viewY; y < viewY+viewHeight; y++ {
+		for x := viewX; x < viewX+viewWidth; x++ {
+			tui.screen.SetContent(x, y, ' ', nil, bgStyle)
+		}
+	}
+
+	boxStyle := tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Primary).Bold(true)
+	tui.drawBox(viewX, viewY, viewWidth, viewHeight, "STORED RESPONSE "+truncateString(tui.viewerTitle, viewWidth-24), boxStyle)
+
+	// Enforce scroll bounds
+	visibleLines := viewHeight - 4
+	maxScroll := len(tui.viewerLines) - visibleLines
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if tui.viewerScroll > maxScroll {
+		tui.viewerScroll = maxScroll
+	}
+	if tui.viewerScroll < 0 {
+		tui.viewerScroll = 0
+	}
+
+	// Request/response head first, dimmed comments, then the body
+	textStyle := tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Text)
+	commentStyle := textStyle.Dim(true)
+	for i := 0; i < visibleLines && tui.viewerScroll+i < len(tui.viewerLines); i++ {
+		line := tui.viewerLines[tui.viewerScroll+i]
+		style := textStyle
+		if len(line) > 0 && line[0] == '#' {
+			style = commentStyle
+		}
+		tui.drawText(viewX+2, viewY+1+i, truncateString(line, viewWidth-4), style)
+	}
+
+	status := fmt.Sprintf("Lines %d-%d of %d | ↑/↓/PgUp/PgDn: Scroll | O/Esc: Close",
+		tui.viewerScroll+1, min(tui.viewerScroll+visibleLines, len(tui.viewerLines)), len(tui.viewerLines))
+	tui.drawText(viewX+2, viewY+viewHeight-2, truncateString(status, viewWidth-4),
+		tcell.StyleDefault.Background(CurrentTheme.Background).Foreground(CurrentTheme.Warning).Bold(true))
+}
+
+// handleViewerKey handles keys while the viewer is open. Returns false for
+// keys the viewer doesn't use.
+func (tui *TUI) handleViewerKey(ev *tcell.EventKey) bool {
+	page := tui.height - 8
+	switch ev.Key() {
+	case tcell.KeyUp:
+		tui.viewerScroll--
+	case tcell.KeyDown:
+		tui.viewerScroll++
+	case tcell.KeyPgUp:
+		tui.viewerScroll -= page
+	case tcell.KeyPgDn:
+		tui.viewerScroll += page
+	case tcell.KeyHome:
+		tui.viewerScroll = 0
+	case tcell.KeyEnd:
+		tui.viewerScroll = len(tui.viewerLines)
+	case tcell.KeyEscape:
+		tui.showResponseViewer = false
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'o', 'O', 'q', 'Q':
+			tui.showResponseViewer = false
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
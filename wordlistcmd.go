@@ -0,0 +1,489 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ===========================================================================
+// WORDLIST MAINTENANCE (pathfinder wordlist ...)
+// ===========================================================================
+
+const wordlistUsage = `Usage: pathfinder wordlist <command> [options] <files>
+
+Commands:
+  dedupe <file>              Drop repeated entries, keeping the first
+  merge <file> <file>...     Combine lists in order, without duplicates
+  sort <file>                Order by hit history (best first, rest in file order)
+  strip <file>               Drop comments and blank lines; -ext also drops extensions
+  normalize <file>           Strip leading slashes, collapse //, decode safe percent-escapes
+  stats <file> [<file>]      Size, extensions, depth; overlap when given two lists
+  diff <a> <b>               Entries only in a (<) and only in b (>)
+  gen <spec>                 Write a generator's paths, e.g. "backup/{dates:2024-01-01..2024-12-31}.zip"
+
+Output goes to stdout unless -o <file> is given. Comments and blank lines
+are never carried over, as with -wordlist. A generator spec can stand in
+for any file (up to 5,000,000 paths; gen streams any size).
+`
+
+// wordlistCommands maps each subcommand to its handler
+var wordlistCommands = map[string]func(args []string) error{
+	"dedupe":    wordlistDedupe,
+	"merge":     wordlistMerge,
+	"sort":      wordlistSort,
+	"strip":     wordlistStrip,
+	"normalize": wordlistNormalize,
+	"stats":     wordlistStats,
+	"diff":      wordlistDiff,
+	"gen":       wordlistGen,
+}
+
+// runWordlistCommand runs "pathfinder wordlist ..." and returns the exit code
+func runWordlistCommand(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(os.Stderr, wordlistUsage)
+		return 2
+	}
+	command, ok := wordlistCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown wordlist command %q\n\n%s", args[0], wordlistUsage)
+		return 2
+	}
+	if err := command(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseInterleaved parses flags that may come before, between or after the
+// file arguments, and returns the files
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var files []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return files, nil
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// wordlistFlags creates a subcommand's flag set with the shared -o flag
+func wordlistFlags(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("wordlist "+name, flag.ContinueOnError)
+	out := fs.String("o", "", "Write the result to this file instead of stdout")
+	return fs, out
+}
+
+// loadWordlists loads every file or generator spec, failing on the first
+// that can't be read
+func loadWordlists(files []string) ([][]string, error) {
+	lists := make([][]string, len(files))
+	for i, file := range files {
+		var words []string
+		var err error
+		if IsGeneratorSpec(file) {
+			var g *PathGenerator
+			if g, err = ParseGenerator(file); err == nil {
+				words, err = g.Paths()
+			}
+		} else {
+			words, err = LoadWordlist(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lists[i] = words
+	}
+	return lists, nil
+}
+
+// writeWordlist writes one entry per line to out, or stdout if out is empty
+func writeWordlist(words []string, out string) error {
+	return writeEntries(out, func(yield func(string) bool) {
+		for _, word := range words {
+			if !yield(word) {
+				return
+			}
+		}
+	})
+}
+
+// writeEntries is writeWordlist for entries produced one at a time
+func writeEntries(out string, each func(yield func(string) bool)) error {
+	var w io.Writer = os.Stdout
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	buf := bufio.NewWriter(w)
+	written := 0
+	var err error
+	each(func(word string) bool {
+		buf.WriteString(word)
+		if err = buf.WriteByte('\n'); err != nil {
+			return false
+		}
+		written++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	if out != "" {
+		fmt.Fprintf(os.Stderr, "[OK] Wrote %d entries to %s\n", written, out)
+	}
+	return nil
+}
+
+// dedupeWords keeps the first occurrence of every entry
+func dedupeWords(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	unique := make([]string, 0, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			unique = append(unique, word)
+		}
+	}
+	return unique
+}
+
+func wordlistDedupe(args []string) error {
+	fs, out := wordlistFlags("dedupe")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("dedupe takes one wordlist")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	unique := dedupeWords(lists[0])
+	fmt.Fprintf(os.Stderr, "%d entries, %d duplicates removed\n", len(unique), len(lists[0])-len(unique))
+	return writeWordlist(unique, *out)
+}
+
+func wordlistMerge(args []string) error {
+	fs, out := wordlistFlags("merge")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) < 2 {
+		return fmt.Errorf("merge takes two or more wordlists")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	var all []string
+	for _, words := range lists {
+		all = append(all, words...)
+	}
+	return writeWordlist(dedupeWords(all), *out)
+}
+
+func wordlistSort(args []string) error {
+	fs, out := wordlistFlags("sort")
+	historyFile := fs.String("history", DefaultHistoryFile(), "Hit-history store to sort by")
+	techs := fs.String("tech", "", "Weigh history from these technologies higher (comma-separated, e.g. PHP,WordPress)")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("sort takes one wordlist")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+	history, err := LoadHitHistory(*historyFile)
+	if err != nil {
+		return err
+	}
+
+	sorted, moved := history.Reorder(lists[0], parseStringList(*techs))
+	fmt.Fprintf(os.Stderr, "%d of %d entries have hit history (%d scans recorded)\n", moved, len(sorted), history.Scans)
+	return writeWordlist(sorted, *out)
+}
+
+func wordlistStrip(args []string) error {
+	fs, out := wordlistFlags("strip")
+	stripExt := fs.Bool("ext", false, "Also remove file extensions (admin.php -> admin)")
+	only := fs.String("only", "", "With -ext, only remove these extensions (comma-separated)")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("strip takes one wordlist")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	allowed := make(map[string]bool)
+	for _, ext := range parseStringList(*only) {
+		allowed[strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+	}
+
+	words := lists[0]
+	if *stripExt {
+		for i, word := range words {
+			ext := wordExtension(word)
+			if ext != "" && (len(allowed) == 0 || allowed[ext]) {
+				words[i] = word[:len(word)-len(ext)-1]
+			}
+		}
+		words = dedupeWords(words)
+	}
+	return writeWordlist(words, *out)
+}
+
+// wordExtension is the lowercase extension of an entry's last segment,
+// without the dot ("" for none or a dotfile like .htaccess)
+func wordExtension(word string) string {
+	last := word[strings.LastIndex(word, "/")+1:]
+	ext := path.Ext(last)
+	if ext == "" || ext == last {
+		return ""
+	}
+	return strings.ToLower(ext[1:])
+}
+
+// normalizeWord strips leading slashes, collapses repeated slashes and
+// decodes %-escapes - unless decoding would produce characters that have to
+// stay encoded in a request path
+func normalizeWord(word string) string {
+	for strings.Contains(word, "//") {
+		word = strings.ReplaceAll(word, "//", "/")
+	}
+	word = strings.TrimPrefix(word, "/")
+
+	if strings.Contains(word, "%") {
+		if decoded, err := url.PathUnescape(word); err == nil && !strings.ContainsAny(decoded, " ?#%\t\r\n") {
+			word = decoded
+		}
+	}
+	return word
+}
+
+func wordlistNormalize(args []string) error {
+	fs, out := wordlistFlags("normalize")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("normalize takes one wordlist")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	var normalized []string
+	for _, word := range lists[0] {
+		n := normalizeWord(word)
+		if n != word {
+			changed++
+		}
+		if n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	unique := dedupeWords(normalized)
+	fmt.Fprintf(os.Stderr, "%d entries changed, %d duplicates removed\n", changed, len(normalized)-len(unique))
+	return writeWordlist(unique, *out)
+}
+
+func wordlistStats(args []string) error {
+	fs := flag.NewFlagSet("wordlist stats", flag.ContinueOnError)
+	top := fs.Int("top", 15, "Extensions to list")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) < 1 || len(files) > 2 {
+		return fmt.Errorf("stats takes one wordlist, or two to compare")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	for i, words := range lists {
+		if i > 0 {
+			fmt.Println()
+		}
+		printWordlistStats(files[i], words, *top)
+	}
+
+	if len(lists) == 2 {
+		a, b := dedupeWords(lists[0]), dedupeWords(lists[1])
+		inB := make(map[string]bool, len(b))
+		for _, word := range b {
+			inB[word] = true
+		}
+		shared := 0
+		for _, word := range a {
+			if inB[word] {
+				shared++
+			}
+		}
+		fmt.Println()
+		fmt.Println("OVERLAP:")
+		fmt.Printf("  Shared entries:      %d\n", shared)
+		fmt.Printf("  Share of %-10s  %.1f%%\n", truncateString(files[0], 10)+":", percentOf(shared, len(a)))
+		fmt.Printf("  Share of %-10s  %.1f%%\n", truncateString(files[1], 10)+":", percentOf(shared, len(b)))
+		fmt.Printf("  Combined unique:     %d\n", len(a)+len(b)-shared)
+	}
+	return nil
+}
+
+func printWordlistStats(name string, words []string, top int) {
+	unique := dedupeWords(words)
+	extensions := make(map[string]int)
+	depths := make(map[int]int)
+	totalLength := 0
+	for _, word := range unique {
+		ext := wordExtension(word)
+		if ext == "" {
+			ext = "(none)"
+		}
+		extensions[ext]++
+		depths[relativeDepth(word)]++
+		totalLength += len(word)
+	}
+
+	fmt.Printf("%s:\n", name)
+	fmt.Printf("  Entries:             %d\n", len(words))
+	fmt.Printf("  Unique:              %d (%d duplicates)\n", len(unique), len(words)-len(unique))
+	if len(unique) > 0 {
+		fmt.Printf("  Average length:      %.1f\n", float64(totalLength)/float64(len(unique)))
+	}
+
+	depthKeys := make([]int, 0, len(depths))
+	for depth := range depths {
+		depthKeys = append(depthKeys, depth)
+	}
+	sort.Ints(depthKeys)
+	var depthParts []string
+	for _, depth := range depthKeys {
+		depthParts = append(depthParts, fmt.Sprintf("%d: %d", depth, depths[depth]))
+	}
+	fmt.Printf("  Segments:            %s\n", strings.Join(depthParts, ", "))
+
+	extKeys := make([]string, 0, len(extensions))
+	for ext := range extensions {
+		extKeys = append(extKeys, ext)
+	}
+	sort.Slice(extKeys, func(i, j int) bool {
+		if extensions[extKeys[i]] != extensions[extKeys[j]] {
+			return extensions[extKeys[i]] > extensions[extKeys[j]]
+		}
+		return extKeys[i] < extKeys[j]
+	})
+	fmt.Println("  Extensions:")
+	for i, ext := range extKeys {
+		if i == top {
+			fmt.Printf("    ... and %d more\n", len(extKeys)-top)
+			break
+		}
+		fmt.Printf("    %-17s %6d  %5.1f%%\n", ext, extensions[ext], percentOf(extensions[ext], len(unique)))
+	}
+}
+
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+func wordlistDiff(args []string) error {
+	fs, out := wordlistFlags("diff")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 2 {
+		return fmt.Errorf("diff takes two wordlists")
+	}
+	lists, err := loadWordlists(files)
+	if err != nil {
+		return err
+	}
+
+	inList := func(words []string) map[string]bool {
+		set := make(map[string]bool, len(words))
+		for _, word := range words {
+			set[word] = true
+		}
+		return set
+	}
+	inA, inB := inList(lists[0]), inList(lists[1])
+
+	var lines []string
+	onlyA, onlyB := 0, 0
+	for _, word := range dedupeWords(lists[0]) {
+		if !inB[word] {
+			lines = append(lines, "< "+word)
+			onlyA++
+		}
+	}
+	for _, word := range dedupeWords(lists[1]) {
+		if !inA[word] {
+			lines = append(lines, "> "+word)
+			onlyB++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d only in %s, %d only in %s\n", onlyA, files[0], onlyB, files[1])
+	return writeWordlist(lines, *out)
+}
+
+func wordlistGen(args []string) error {
+	fs, out := wordlistFlags("gen")
+	specs, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(specs) != 1 {
+		return fmt.Errorf("gen takes one generator spec")
+	}
+	g, err := ParseGenerator(specs[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d paths\n", g.Count())
+	return writeEntries(*out, g.Each)
+}